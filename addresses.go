@@ -1,6 +1,7 @@
 package busha_commerce_go
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/gobuffalo/uuid"
@@ -32,23 +33,38 @@ type ListAddressesResponse struct {
 }
 
 func (s *AddressService) Create(req *AddressRequest) (*AddressResponse, error) {
+	return s.CreateWithContext(context.Background(), req)
+}
+
+func (s *AddressService) CreateWithContext(ctx context.Context, req *AddressRequest) (*AddressResponse, error) {
 	var resp = new(AddressResponse)
-	err := s.client.call("POST", "/addresses", req, &resp)
+	if err := s.client.validate(req); err != nil {
+		return resp, err
+	}
+	err := s.client.call(ctx, OpAddressCreate, "POST", "/addresses", req, &resp)
 	return resp, err
 }
 
 func (s *AddressService) List(params ListParameters) (*ListAddressesResponse, error) {
+	return s.ListWithContext(context.Background(), params)
+}
+
+func (s *AddressService) ListWithContext(ctx context.Context, params ListParameters) (*ListAddressesResponse, error) {
 	var resp = new(ListAddressesResponse)
-	err := s.client.call("GET", fmt.Sprintf("/addresses?sort=%s&limit=%d&page=%d&currency=%s",
+	err := s.client.call(ctx, OpAddressList, "GET", fmt.Sprintf("/addresses?sort=%s&limit=%d&page=%d&currency=%s",
 		params.Sort, params.Limit, params.Page, params.Currency), params, &resp)
 	return resp, err
 }
 
 func (s *AddressService) Get(id string) (*AddressResponse, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+func (s *AddressService) GetWithContext(ctx context.Context, id string) (*AddressResponse, error) {
 	var resp = new(AddressResponse)
 	if id == "" {
 		return nil, errors.New("no addressID provided")
 	}
-	err := s.client.call("GET", fmt.Sprintf("/addresses/%s", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpAddressGet, "GET", fmt.Sprintf("/addresses/%s", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
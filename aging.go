@@ -0,0 +1,87 @@
+package busha_commerce_go
+
+import "time"
+
+// AgingBucket groups unpaid invoices by how long they are overdue.
+type AgingBucket string
+
+const (
+	AgingCurrent  AgingBucket = "current"
+	Aging1To30    AgingBucket = "1-30"
+	Aging31To60   AgingBucket = "31-60"
+	Aging61To90   AgingBucket = "61-90"
+	AgingOver90   AgingBucket = "90+"
+	agingNotOwing AgingBucket = ""
+)
+
+// AgingBuckets lists the buckets in report order.
+var AgingBuckets = []AgingBucket{AgingCurrent, Aging1To30, Aging31To60, Aging61To90, AgingOver90}
+
+// IsOverdue reports whether the invoice is unpaid past its due date at now.
+func (i *Invoice) IsOverdue(now time.Time) bool {
+	return i.DueDate != nil && i.Status.AwaitsPayment() && now.After(*i.DueDate)
+}
+
+// DaysOverdue returns the number of started days since the due date,
+// or 0 when the invoice is not overdue.
+func (i *Invoice) DaysOverdue(now time.Time) int {
+	if !i.IsOverdue(now) {
+		return 0
+	}
+	late := now.Sub(*i.DueDate)
+	days := int(late / (24 * time.Hour))
+	if late%(24*time.Hour) != 0 {
+		days++
+	}
+	return days
+}
+
+// AgingBucket returns the bucket of an unpaid invoice at now. Invoices that
+// do not await payment have no bucket.
+func (i *Invoice) AgingBucket(now time.Time) AgingBucket {
+	if !i.Status.AwaitsPayment() {
+		return agingNotOwing
+	}
+	switch days := i.DaysOverdue(now); {
+	case days == 0:
+		return AgingCurrent
+	case days <= 30:
+		return Aging1To30
+	case days <= 60:
+		return Aging31To60
+	case days <= 90:
+		return Aging61To90
+	default:
+		return AgingOver90
+	}
+}
+
+// AgingSummary totals the unpaid invoices of one bucket.
+type AgingSummary struct {
+	Bucket AgingBucket
+	Count  int
+	//Totals is the amount owed per local currency
+	Totals   map[string]float64
+	Invoices []*Invoice
+}
+
+// Aging groups the unpaid invoices into AgingBuckets at now. Every bucket
+// is returned, in report order, even when empty.
+func Aging(invoices []*Invoice, now time.Time) []AgingSummary {
+	out := make([]AgingSummary, len(AgingBuckets))
+	index := make(map[AgingBucket]int, len(AgingBuckets))
+	for i, b := range AgingBuckets {
+		out[i] = AgingSummary{Bucket: b, Totals: make(map[string]float64)}
+		index[b] = i
+	}
+	for _, inv := range invoices {
+		i, ok := index[inv.AgingBucket(now)]
+		if !ok {
+			continue
+		}
+		out[i].Count++
+		out[i].Totals[inv.LocalCurrency] += inv.LocalAmount
+		out[i].Invoices = append(out[i].Invoices, inv)
+	}
+	return out
+}
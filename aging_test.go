@@ -0,0 +1,56 @@
+package busha_commerce_go
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoice_DaysOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	due := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+	tests := []struct {
+		name    string
+		invoice Invoice
+		overdue bool
+		days    int
+		bucket  AgingBucket
+	}{
+		{name: "no due date", invoice: Invoice{Status: InvoiceUnpaid}, bucket: AgingCurrent},
+		{name: "not yet due", invoice: Invoice{Status: InvoiceUnpaid, DueDate: due(-time.Hour)}, bucket: AgingCurrent},
+		{name: "an hour late", invoice: Invoice{Status: InvoiceUnpaid, DueDate: due(time.Hour)}, overdue: true, days: 1, bucket: Aging1To30},
+		{name: "30 days late", invoice: Invoice{Status: InvoiceUnpaid, DueDate: due(30 * 24 * time.Hour)}, overdue: true, days: 30, bucket: Aging1To30},
+		{name: "45 days late", invoice: Invoice{Status: "UNPAID", DueDate: due(45 * 24 * time.Hour)}, overdue: true, days: 45, bucket: Aging31To60},
+		{name: "61 days late", invoice: Invoice{Status: InvoiceUnpaid, DueDate: due(61 * 24 * time.Hour)}, overdue: true, days: 61, bucket: Aging61To90},
+		{name: "100 days late", invoice: Invoice{Status: InvoiceUnpaid, DueDate: due(100 * 24 * time.Hour)}, overdue: true, days: 100, bucket: AgingOver90},
+		{name: "paid late", invoice: Invoice{Status: InvoicePaid, DueDate: due(100 * 24 * time.Hour)}, bucket: agingNotOwing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.overdue, tt.invoice.IsOverdue(now))
+			assert.Equal(t, tt.days, tt.invoice.DaysOverdue(now))
+			assert.Equal(t, tt.bucket, tt.invoice.AgingBucket(now))
+		})
+	}
+}
+
+func TestAging(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	late := now.Add(-40 * 24 * time.Hour)
+	invoices := []*Invoice{
+		{Status: InvoiceUnpaid, LocalAmount: 100, LocalCurrency: "NGN"},
+		{Status: InvoiceUnpaid, LocalAmount: 50, LocalCurrency: "NGN", DueDate: &late},
+		{Status: InvoiceUnpaid, LocalAmount: 10, LocalCurrency: "USD", DueDate: &late},
+		{Status: InvoiceVoid, LocalAmount: 999, LocalCurrency: "NGN", DueDate: &late},
+	}
+
+	got := Aging(invoices, now)
+	assert.Len(t, got, len(AgingBuckets))
+	assert.Equal(t, AgingCurrent, got[0].Bucket)
+	assert.Equal(t, 1, got[0].Count)
+	assert.Equal(t, Aging31To60, got[2].Bucket)
+	assert.Equal(t, 2, got[2].Count)
+	assert.Equal(t, map[string]float64{"NGN": 50, "USD": 10}, got[2].Totals)
+	assert.Equal(t, 0, got[4].Count)
+}
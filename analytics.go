@@ -0,0 +1,246 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChargeTypePaymentLink is the type of charges created from a payment link.
+const ChargeTypePaymentLink = "payment_link"
+
+// PaymentLinkStats is the performance of one payment link.
+type PaymentLinkStats struct {
+	LinkID string
+	//Charges is the number of charges created from the link
+	Charges int
+	//Paid counts completed and resolved charges
+	Paid     int
+	Expired  int
+	Canceled int
+	//ConversionRate and ExpiryRate are Paid and Expired over Charges
+	ConversionRate float64
+	ExpiryRate     float64
+	//Revenue is the local amount of the payments received per currency, so
+	//donations and under- or overpaid charges count what was actually paid
+	Revenue map[string]float64
+	//AverageTimeToPay is the mean time from a charge's creation to its payment
+	AverageTimeToPay time.Duration
+}
+
+// PaymentLinkAnalytics aggregates charges, and charge events, per payment
+// link. Charges are deduplicated by ID, keeping the version with the
+// longest timeline.
+type PaymentLinkAnalytics struct {
+	//LinkOf returns the payment link a charge was created from, or "" to
+	//ignore it. It defaults to the charge's resource ID when its type is
+	//ChargeTypePaymentLink.
+	LinkOf func(c *Charge) string
+	//PageSize is the number of charges fetched per page by Collect
+	PageSize int64
+
+	mu      sync.Mutex
+	charges map[string]*Charge
+}
+
+// NewPaymentLinkAnalytics returns an empty PaymentLinkAnalytics.
+func NewPaymentLinkAnalytics() *PaymentLinkAnalytics {
+	return &PaymentLinkAnalytics{
+		LinkOf:   chargePaymentLink,
+		PageSize: 100,
+		charges:  make(map[string]*Charge),
+	}
+}
+
+func chargePaymentLink(c *Charge) string {
+	if c.Type == nil || *c.Type != ChargeTypePaymentLink || c.ResourceID == nil {
+		return ""
+	}
+	return c.ResourceID.String()
+}
+
+// AddCharge adds c to the aggregate.
+func (a *PaymentLinkAnalytics) AddCharge(c *Charge) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	id := c.Id.String()
+	if prev, ok := a.charges[id]; ok && len(prev.Timeline) > len(c.Timeline) {
+		return
+	}
+	a.charges[id] = c
+}
+
+// AddEvent adds the charge carried by a charge event.
+func (a *PaymentLinkAnalytics) AddEvent(e *Event) {
+	d := e.Data
+	a.AddCharge(&Charge{
+		Id:            d.Id,
+		BusinessId:    d.BusinessId,
+		Reference:     d.Reference,
+		HostedUrl:     d.HostedUrl,
+		PriceFixed:    d.PriceFixed,
+		Meta:          d.Meta,
+		ExpiresAt:     d.ExpiresAt,
+		Timeline:      d.TimeLine,
+		Payments:      d.Payment,
+		Pricing:       d.Pricing,
+		Addresses:     d.Addresses,
+		CallbackUrl:   d.CallBackUrl,
+		LocalCurrency: d.LocalCurrency,
+		LocalAmount:   localAmount(d.Pricing),
+		Type:          d.Type,
+		ResourceID:    d.ResourceID,
+	})
+}
+
+// localAmount returns the local amount of a charge from its pricing, as
+// events carry no local_amount.
+func localAmount(pricing []ChargePricing) float64 {
+	for _, p := range pricing {
+		if p.IsLocal {
+			return p.Amount
+		}
+	}
+	return 0
+}
+
+// Collect walks every charge of the business and adds it.
+func (a *PaymentLinkAnalytics) Collect(ctx context.Context, charges *ChargeService) error {
+	for page := int64(1); ; page++ {
+		resp, err := charges.ListWithContext(ctx, ListParameters{Page: page, Limit: a.PageSize})
+		if err != nil {
+			return err
+		}
+		for _, c := range resp.Data {
+			a.AddCharge(c)
+		}
+		if len(resp.Data) == 0 || page >= int64(resp.Pagination.TotalPages) {
+			return nil
+		}
+	}
+}
+
+// Stats returns the stats of every payment link with at least one charge,
+// ordered by link ID.
+func (a *PaymentLinkAnalytics) Stats() []PaymentLinkStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	linkOf := a.LinkOf
+	if linkOf == nil {
+		linkOf = chargePaymentLink
+	}
+
+	byLink := make(map[string]*PaymentLinkStats)
+	waits := make(map[string]time.Duration)
+	for _, c := range a.charges {
+		link := linkOf(c)
+		if link == "" {
+			continue
+		}
+		st, ok := byLink[link]
+		if !ok {
+			st = &PaymentLinkStats{LinkID: link, Revenue: make(map[string]float64)}
+			byLink[link] = st
+		}
+		st.Charges++
+		switch c.Status() {
+		case ChargeStatusCompleted, ChargeStatusResolved:
+			st.Paid++
+			for _, p := range c.Payments {
+				currency := p.LocalCurrency
+				if currency == "" {
+					currency = c.LocalCurrency
+				}
+				st.Revenue[currency] = roundAmount(st.Revenue[currency] + p.LocalAmount)
+			}
+			waits[link] += timeToPay(c)
+		case ChargeStatusExpired:
+			st.Expired++
+		case ChargeStatusCanceled:
+			st.Canceled++
+		}
+	}
+
+	out := make([]PaymentLinkStats, 0, len(byLink))
+	for link, st := range byLink {
+		st.ConversionRate = float64(st.Paid) / float64(st.Charges)
+		st.ExpiryRate = float64(st.Expired) / float64(st.Charges)
+		if st.Paid > 0 {
+			st.AverageTimeToPay = waits[link] / time.Duration(st.Paid)
+		}
+		out = append(out, *st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].LinkID < out[j].LinkID })
+	return out
+}
+
+// timeToPay is the time between the first timeline entry and the first
+// completed or resolved one.
+func timeToPay(c *Charge) time.Duration {
+	var created, paid time.Time
+	for _, t := range c.Timeline {
+		if created.IsZero() || t.CreatedAt.Before(created) {
+			created = t.CreatedAt
+		}
+		switch strings.ToUpper(t.Status) {
+		case ChargeStatusCompleted, ChargeStatusResolved:
+			if paid.IsZero() || t.CreatedAt.Before(paid) {
+				paid = t.CreatedAt
+			}
+		}
+	}
+	if created.IsZero() || paid.IsZero() {
+		return 0
+	}
+	return paid.Sub(created)
+}
+
+// WritePaymentLinkStatsCSV writes stats as CSV with one revenue_<currency>
+// column per currency seen.
+func WritePaymentLinkStatsCSV(w io.Writer, stats []PaymentLinkStats) error {
+	seen := make(map[string]bool)
+	var currencies []string
+	for _, st := range stats {
+		for cur := range st.Revenue {
+			if !seen[cur] {
+				seen[cur] = true
+				currencies = append(currencies, cur)
+			}
+		}
+	}
+	sort.Strings(currencies)
+
+	cw := csv.NewWriter(w)
+	header := []string{"link_id", "charges", "paid", "expired", "canceled", "conversion_rate", "expiry_rate", "average_time_to_pay_seconds"}
+	for _, cur := range currencies {
+		header = append(header, "revenue_"+cur)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, st := range stats {
+		rec := []string{
+			st.LinkID,
+			strconv.Itoa(st.Charges),
+			strconv.Itoa(st.Paid),
+			strconv.Itoa(st.Expired),
+			strconv.Itoa(st.Canceled),
+			strconv.FormatFloat(st.ConversionRate, 'f', 4, 64),
+			strconv.FormatFloat(st.ExpiryRate, 'f', 4, 64),
+			strconv.FormatFloat(st.AverageTimeToPay.Seconds(), 'f', 0, 64),
+		}
+		for _, cur := range currencies {
+			rec = append(rec, strconv.FormatFloat(st.Revenue[cur], 'f', 2, 64))
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,129 @@
+package busha_commerce_go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	analyticsLinkA = uuid.Must(uuid.FromString("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"))
+	analyticsLinkB = uuid.Must(uuid.FromString("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"))
+)
+
+// analyticsCharge returns a charge priced at price that received one payment
+// of paid, or none when paid is zero.
+func analyticsCharge(n int, link *uuid.UUID, price, paid float64, currency string, statuses ...string) *Charge {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Charge{
+		Id:            uuid.Must(uuid.FromString(fmt.Sprintf("00000000-0000-0000-0000-%012d", n))),
+		LocalAmount:   price,
+		LocalCurrency: currency,
+	}
+	if paid > 0 {
+		c.Payments = []ChargePayment{{LocalAmount: paid, LocalCurrency: currency}}
+	}
+	if link != nil {
+		typ := ChargeTypePaymentLink
+		c.Type, c.ResourceID = &typ, link
+	}
+	for i, s := range statuses {
+		c.Timeline = append(c.Timeline, ChargeTimeline{Status: s, CreatedAt: start.Add(time.Duration(i) * 10 * time.Minute)})
+	}
+	return c
+}
+
+func TestPaymentLinkAnalytics_Stats(t *testing.T) {
+	a := NewPaymentLinkAnalytics()
+	a.AddCharge(analyticsCharge(1, &analyticsLinkA, 5000, 5000, "NGN", "NEW", "PENDING", "COMPLETED"))
+	a.AddCharge(analyticsCharge(2, &analyticsLinkA, 10, 10, "USD", "NEW", "completed"))
+	a.AddCharge(analyticsCharge(3, &analyticsLinkA, 5000, 0, "NGN", "NEW", "EXPIRED"))
+	a.AddCharge(analyticsCharge(4, &analyticsLinkA, 5000, 0, "NGN", "NEW"))
+	a.AddCharge(analyticsCharge(5, &analyticsLinkB, 2000, 0, "NGN", "NEW", "CANCELED"))
+	a.AddCharge(analyticsCharge(6, nil, 9999, 9999, "NGN", "NEW", "COMPLETED"))
+	// A donation has no price, only what the customer chose to pay.
+	a.AddCharge(analyticsCharge(7, &analyticsLinkB, 0, 2500, "NGN", "NEW", "COMPLETED"))
+	// An underpaid charge resolved by the merchant counts the amount received.
+	a.AddCharge(analyticsCharge(8, &analyticsLinkB, 5000, 4800, "NGN", "NEW", "UNRESOLVED", "RESOLVED"))
+	// An older copy of charge 1 does not replace the newer one.
+	a.AddCharge(analyticsCharge(1, &analyticsLinkA, 5000, 0, "NGN", "NEW"))
+
+	stats := a.Stats()
+	assert.Len(t, stats, 2)
+
+	st := stats[0]
+	assert.Equal(t, analyticsLinkA.String(), st.LinkID)
+	assert.Equal(t, 4, st.Charges)
+	assert.Equal(t, 2, st.Paid)
+	assert.Equal(t, 1, st.Expired)
+	assert.Equal(t, 0.5, st.ConversionRate)
+	assert.Equal(t, 0.25, st.ExpiryRate)
+	assert.Equal(t, map[string]float64{"NGN": 5000, "USD": 10}, st.Revenue)
+	assert.Equal(t, 15*time.Minute, st.AverageTimeToPay)
+
+	st = stats[1]
+	assert.Equal(t, 3, st.Charges)
+	assert.Equal(t, 1, st.Canceled)
+	assert.Equal(t, 2, st.Paid)
+	assert.Equal(t, map[string]float64{"NGN": 7300}, st.Revenue)
+	assert.Equal(t, 15*time.Minute, st.AverageTimeToPay)
+}
+
+func TestPaymentLinkAnalytics_AddEvent(t *testing.T) {
+	typ := ChargeTypePaymentLink
+	a := NewPaymentLinkAnalytics()
+	a.AddEvent(&Event{Data: EventData{
+		Id:            uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000001")),
+		LocalCurrency: "NGN",
+		Type:          &typ,
+		ResourceID:    &analyticsLinkB,
+		Pricing:       []ChargePricing{{CurrencyId: "USDT", Amount: 3}, {CurrencyId: "NGN", Amount: 4500, IsLocal: true}},
+		TimeLine:      []ChargeTimeline{{Status: "NEW"}, {Status: "COMPLETED", CreatedAt: time.Unix(60, 0)}},
+		Payment:       []ChargePayment{{LocalAmount: 4500, LocalCurrency: "NGN"}},
+	}})
+
+	stats := a.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, map[string]float64{"NGN": 4500}, stats[0].Revenue)
+}
+
+func TestPaymentLinkAnalytics_Collect(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = fmt.Fprintf(w, `{"status":"success","pagination":{"page":1,"total_pages":2},"data":[
+				{"id":"00000000-0000-0000-0000-000000000001","type":"payment_link","resource_id":%q,"local_amount":"100","local_currency":"NGN","timeline":[{"status":"COMPLETED"}],"payments":[{"local_amount":"100","local_currency":"NGN"}]}
+			]}`, analyticsLinkA)
+		default:
+			_, _ = fmt.Fprintf(w, `{"status":"success","pagination":{"page":2,"total_pages":2},"data":[
+				{"id":"00000000-0000-0000-0000-000000000002","type":"payment_link","resource_id":%q,"local_amount":"100","local_currency":"NGN","timeline":[{"status":"EXPIRED"}]}
+			]}`, analyticsLinkA)
+		}
+	})
+
+	a := NewPaymentLinkAnalytics()
+	assert.NoError(t, a.Collect(context.Background(), client.Charge))
+	stats := a.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, 2, stats[0].Charges)
+	assert.Equal(t, 0.5, stats[0].ExpiryRate)
+}
+
+func TestWritePaymentLinkStatsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WritePaymentLinkStatsCSV(&buf, []PaymentLinkStats{
+		{LinkID: "a", Charges: 4, Paid: 2, Expired: 1, ConversionRate: 0.5, ExpiryRate: 0.25,
+			Revenue: map[string]float64{"NGN": 5000, "USD": 10}, AverageTimeToPay: 15 * time.Minute},
+		{LinkID: "b", Charges: 1, Canceled: 1, Revenue: map[string]float64{}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "link_id,charges,paid,expired,canceled,conversion_rate,expiry_rate,average_time_to_pay_seconds,revenue_NGN,revenue_USD\n"+
+		"a,4,2,1,0,0.5000,0.2500,900,5000.00,10.00\n"+
+		"b,1,0,0,1,0.0000,0.0000,0,0.00,0.00\n", buf.String())
+}
@@ -0,0 +1,264 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker for one operation.
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen is returned without calling the API while the circuit
+// for an operation is open.
+type ErrCircuitOpen struct {
+	Operation string
+	//RetryAt is when the circuit lets a trial request through again
+	RetryAt time.Time
+}
+
+func (e ErrCircuitOpen) Error() string {
+	return fmt.Sprintf("circuit open for %s until %s", e.Operation, e.RetryAt.Format(time.RFC3339))
+}
+
+// IsCircuitOpen reports whether err was caused by an open circuit.
+func IsCircuitOpen(err error) bool {
+	var e ErrCircuitOpen
+	return errors.As(err, &e)
+}
+
+// CircuitBreakerSettings configures when a circuit opens and recovers.
+type CircuitBreakerSettings struct {
+	//FailureThreshold is the number of consecutive failures that opens the circuit
+	FailureThreshold int
+	//OpenTimeout is how long the circuit stays open before allowing trial requests
+	OpenTimeout time.Duration
+	//HalfOpenSuccesses is the number of successful trial requests needed to close the circuit
+	HalfOpenSuccesses int
+}
+
+// DefaultCircuitBreakerSettings opens after 5 consecutive failures and
+// tries again after 30 seconds.
+var DefaultCircuitBreakerSettings = CircuitBreakerSettings{
+	FailureThreshold:  5,
+	OpenTimeout:       30 * time.Second,
+	HalfOpenSuccesses: 1,
+}
+
+// CircuitBreaker fails calls fast while the API keeps failing. Each
+// operation has its own circuit. Transport errors, 429 and 5xx responses
+// count as failures; other API errors do not.
+type CircuitBreaker struct {
+	//OnStateChange is called whenever the circuit of an operation changes state
+	OnStateChange func(operation string, from, to CircuitState)
+
+	mu         sync.Mutex
+	settings   CircuitBreakerSettings
+	operations map[string]CircuitBreakerSettings
+	circuits   map[string]*circuit
+	now        func() time.Time
+}
+
+type circuit struct {
+	state     CircuitState
+	failures  int
+	successes int
+	inFlight  bool
+	openedAt  time.Time
+}
+
+// NewCircuitBreaker returns a CircuitBreaker applying settings to every operation.
+func NewCircuitBreaker(settings CircuitBreakerSettings) *CircuitBreaker {
+	return &CircuitBreaker{
+		settings:   settings.withDefaults(),
+		operations: make(map[string]CircuitBreakerSettings),
+		circuits:   make(map[string]*circuit),
+		now:        time.Now,
+	}
+}
+
+func (s CircuitBreakerSettings) withDefaults() CircuitBreakerSettings {
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = DefaultCircuitBreakerSettings.FailureThreshold
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = DefaultCircuitBreakerSettings.OpenTimeout
+	}
+	if s.HalfOpenSuccesses <= 0 {
+		s.HalfOpenSuccesses = DefaultCircuitBreakerSettings.HalfOpenSuccesses
+	}
+	return s
+}
+
+// SetOperation overrides the settings for one operation i.e. OpChargeCreate.
+func (b *CircuitBreaker) SetOperation(operation string, settings CircuitBreakerSettings) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.operations[operation] = settings.withDefaults()
+}
+
+// State returns the current state of the circuit for operation.
+func (b *CircuitBreaker) State(operation string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cb, ok := b.circuits[operation]; ok {
+		return cb.state
+	}
+	return CircuitClosed
+}
+
+func (b *CircuitBreaker) settingsFor(operation string) CircuitBreakerSettings {
+	if s, ok := b.operations[operation]; ok {
+		return s
+	}
+	return b.settings
+}
+
+// stateChange is a transition reported to OnStateChange once b.mu is released,
+// so the hook may call back into the breaker or the client.
+type stateChange struct {
+	operation string
+	from, to  CircuitState
+}
+
+func (b *CircuitBreaker) notify(changes []stateChange) {
+	if b.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		b.OnStateChange(c.operation, c.from, c.to)
+	}
+}
+
+// allow reports whether a call may proceed, moving an open circuit to
+// half-open once its timeout has elapsed.
+func (b *CircuitBreaker) allow(operation string) error {
+	var changes []stateChange
+	defer func() { b.notify(changes) }()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb, ok := b.circuits[operation]
+	if !ok {
+		cb = &circuit{}
+		b.circuits[operation] = cb
+	}
+	s := b.settingsFor(operation)
+	switch cb.state {
+	case CircuitOpen:
+		retryAt := cb.openedAt.Add(s.OpenTimeout)
+		if b.now().Before(retryAt) {
+			return ErrCircuitOpen{Operation: operation, RetryAt: retryAt}
+		}
+		changes = b.transition(changes, operation, cb, CircuitHalfOpen)
+		cb.inFlight = true
+	case CircuitHalfOpen:
+		if cb.inFlight {
+			return ErrCircuitOpen{Operation: operation, RetryAt: b.now()}
+		}
+		cb.inFlight = true
+	}
+	return nil
+}
+
+func (b *CircuitBreaker) record(operation string, failed bool) {
+	var changes []stateChange
+	defer func() { b.notify(changes) }()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb := b.circuits[operation]
+	s := b.settingsFor(operation)
+	cb.inFlight = false
+	switch {
+	case failed && cb.state == CircuitHalfOpen:
+		changes = b.transition(changes, operation, cb, CircuitOpen)
+	case failed:
+		cb.failures++
+		if cb.failures >= s.FailureThreshold {
+			changes = b.transition(changes, operation, cb, CircuitOpen)
+		}
+	case cb.state == CircuitHalfOpen:
+		cb.successes++
+		if cb.successes >= s.HalfOpenSuccesses {
+			changes = b.transition(changes, operation, cb, CircuitClosed)
+		}
+	default:
+		cb.failures = 0
+	}
+}
+
+// release frees the trial slot of a call whose caller gave up, without
+// counting it as a success or a failure.
+func (b *CircuitBreaker) release(operation string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.circuits[operation].inFlight = false
+}
+
+func (b *CircuitBreaker) transition(changes []stateChange, operation string, cb *circuit, to CircuitState) []stateChange {
+	from := cb.state
+	cb.state, cb.failures, cb.successes = to, 0, 0
+	if to == CircuitOpen {
+		cb.openedAt = b.now()
+	}
+	if from != to {
+		changes = append(changes, stateChange{operation: operation, from: from, to: to})
+	}
+	return changes
+}
+
+// Middleware applies the circuit breaker to each call passing through next.
+func (b *CircuitBreaker) Middleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Result, error) {
+		if err := b.allow(req.Operation); err != nil {
+			return nil, err
+		}
+		res, err := next(ctx, req)
+		if err != nil && ctx.Err() != nil {
+			b.release(req.Operation)
+			return res, err
+		}
+		b.record(req.Operation, isFailure(res, err))
+		return res, err
+	}
+}
+
+// isFailure reports whether the outcome of a call indicates the API is unhealthy.
+func isFailure(res *Result, err error) bool {
+	if err == nil {
+		return false
+	}
+	if res != nil && res.StatusCode != 0 {
+		return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	}
+	return true
+}
+
+// SetCircuitBreaker makes the client fail fast with ErrCircuitOpen while
+// the API is failing. Pass nil to disable it.
+func (c *Client) SetCircuitBreaker(b *CircuitBreaker) {
+	c.breaker = b
+}
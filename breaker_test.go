@@ -0,0 +1,127 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var failing int32 = 1
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error":{"name":"BadGateway","message":"Bad gateway"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+	})
+
+	now := time.Unix(1700000000, 0)
+	var changes []string
+	breaker := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute})
+	breaker.now = func() time.Time { return now }
+	breaker.OnStateChange = func(op string, from, to CircuitState) {
+		changes = append(changes, op+":"+from.String()+"->"+to.String())
+	}
+	client.SetCircuitBreaker(breaker)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Charge.Get("id")
+		assert.Error(t, err)
+		assert.False(t, IsCircuitOpen(err))
+	}
+	assert.Equal(t, CircuitOpen, breaker.State(OpChargeGet))
+
+	_, err := client.Charge.Get("id")
+	assert.True(t, IsCircuitOpen(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, CircuitClosed, breaker.State(OpChargeCreate))
+
+	atomic.StoreInt32(&failing, 0)
+	now = now.Add(time.Minute)
+	_, err = client.Charge.Get("id")
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State(OpChargeGet))
+	assert.Equal(t, []string{
+		"charge.get:closed->open",
+		"charge.get:open->half-open",
+		"charge.get:half-open->closed",
+	}, changes)
+}
+
+func TestCircuitBreaker_ClientErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"name":"ValidationError","message":"Invalid request"}}`))
+	})
+	breaker := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1})
+	client.SetCircuitBreaker(breaker)
+
+	_, err := client.Charge.Get("id")
+	assert.Error(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State(OpChargeGet))
+}
+
+func TestCircuitBreaker_CancelledTrial(t *testing.T) {
+	cancelled := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-cancelled:
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error":{"name":"BadGateway","message":"Bad gateway"}}`))
+		}
+	})
+	now := time.Unix(1700000000, 0)
+	breaker := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	breaker.now = func() time.Time { return now }
+	client.SetCircuitBreaker(breaker)
+
+	_, _ = client.Charge.Get("id")
+	assert.Equal(t, CircuitOpen, breaker.State(OpChargeGet))
+
+	// A trial abandoned by its caller neither closes nor reopens the circuit.
+	close(cancelled)
+	now = now.Add(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Charge.GetWithContext(ctx, "id")
+	assert.Error(t, err)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(OpChargeGet))
+
+	_, err = client.Charge.GetWithContext(ctx, "id")
+	assert.False(t, IsCircuitOpen(err), "the trial slot should be free again")
+}
+
+func TestCircuitBreaker_ReentrantHook(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"error":{"name":"BadGateway","message":"Bad gateway"}}`))
+	})
+	breaker := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1})
+	var seen CircuitState
+	breaker.OnStateChange = func(op string, from, to CircuitState) {
+		seen = breaker.State(op)
+	}
+	client.SetCircuitBreaker(breaker)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = client.Charge.Get("id")
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("OnStateChange deadlocked calling State")
+	}
+	assert.Equal(t, CircuitOpen, seen)
+}
@@ -0,0 +1,182 @@
+package busha_commerce_go
+
+import "time"
+
+// ChargeBuilder builds a validated ChargeRequest.
+//
+//	req, err := NewCharge().Fixed(5000, "NGN").Reference("order-1234").Meta(order).Build()
+type ChargeBuilder struct {
+	req ChargeRequest
+	err error
+}
+
+// NewCharge starts a charge whose price is not fixed.
+func NewCharge() *ChargeBuilder {
+	return &ChargeBuilder{}
+}
+
+// Fixed fixes the price of the charge to amount in currency.
+func (b *ChargeBuilder) Fixed(amount int, currency string) *ChargeBuilder {
+	b.req.FixedPrice = true
+	b.req.LocalAmount = amount
+	b.req.LocalCurrency = currency
+	return b
+}
+
+// Reference sets a custom reference for the charge.
+func (b *ChargeBuilder) Reference(reference string) *ChargeBuilder {
+	b.req.Reference = &reference
+	return b
+}
+
+// SuccessURL sets where the customer is redirected once the charge is successful.
+func (b *ChargeBuilder) SuccessURL(u string) *ChargeBuilder {
+	b.req.SuccessRedirectURL = &u
+	return b
+}
+
+// CancelURL sets where the customer is redirected once the charge is cancelled.
+func (b *ChargeBuilder) CancelURL(u string) *ChargeBuilder {
+	b.req.CancelRedirectURL = &u
+	return b
+}
+
+// Meta marshals v into the charge metadata.
+func (b *ChargeBuilder) Meta(v interface{}) *ChargeBuilder {
+	if err := b.req.SetMeta(v); err != nil && b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// Build returns the request, or the first error met while building or validating it.
+func (b *ChargeBuilder) Build() (*ChargeRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	req := b.req
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// InvoiceBuilder builds a validated InvoiceRequest.
+//
+//	req, err := NewInvoice("Consulting").Customer("Astro", "astro@example.com").Amount(5000, "NGN").DueIn(14 * 24 * time.Hour).Build()
+type InvoiceBuilder struct {
+	req   InvoiceRequest
+	items LineItems
+}
+
+// NewInvoice starts an invoice named name.
+func NewInvoice(name string) *InvoiceBuilder {
+	return &InvoiceBuilder{req: InvoiceRequest{Name: name}}
+}
+
+// Customer sets who the invoice is addressed to.
+func (b *InvoiceBuilder) Customer(name, email string) *InvoiceBuilder {
+	b.req.CustomerName = name
+	b.req.CustomerEmail = email
+	return b
+}
+
+// Amount sets the amount due in currency.
+func (b *InvoiceBuilder) Amount(amount float64, currency string) *InvoiceBuilder {
+	b.req.LocalAmount = amount
+	b.req.LocalCurrency = currency
+	return b
+}
+
+// Currency sets the local currency of the invoice.
+func (b *InvoiceBuilder) Currency(currency string) *InvoiceBuilder {
+	b.req.LocalCurrency = currency
+	return b
+}
+
+// Description sets the invoice description.
+func (b *InvoiceBuilder) Description(description string) *InvoiceBuilder {
+	b.req.Description = description
+	return b
+}
+
+// LineItems adds line items. The amount is set to their total when the invoice is built.
+func (b *InvoiceBuilder) LineItems(items ...LineItem) *InvoiceBuilder {
+	b.items = append(b.items, items...)
+	return b
+}
+
+// DueDate sets the date by which the invoice will be void.
+func (b *InvoiceBuilder) DueDate(t time.Time) *InvoiceBuilder {
+	b.req.DueDate = &t
+	return b
+}
+
+// DueIn sets the due date to d from now.
+func (b *InvoiceBuilder) DueIn(d time.Duration) *InvoiceBuilder {
+	return b.DueDate(time.Now().Add(d))
+}
+
+// Build returns the request, or the validation error.
+func (b *InvoiceBuilder) Build() (*InvoiceRequest, error) {
+	req := b.req
+	if len(b.items) > 0 {
+		if err := req.SetLineItems(b.items); err != nil {
+			return nil, err
+		}
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// PaymentLinkBuilder builds a validated PaymentLinkRequest.
+//
+//	req, err := NewPaymentLink("iPhone 14 Pro").Fixed(800, "NGN").RequestInfo("name", "email").Build()
+type PaymentLinkBuilder struct {
+	req PaymentLinkRequest
+}
+
+// NewPaymentLink starts a donation payment link named name.
+func NewPaymentLink(name string) *PaymentLinkBuilder {
+	return &PaymentLinkBuilder{req: PaymentLinkRequest{Name: name, PaymentLinkType: Donation}}
+}
+
+// Description sets the payment link description.
+func (b *PaymentLinkBuilder) Description(description string) *PaymentLinkBuilder {
+	b.req.Description = description
+	return b
+}
+
+// Fixed makes the payment link charge amount in currency.
+func (b *PaymentLinkBuilder) Fixed(amount float64, currency string) *PaymentLinkBuilder {
+	b.req.PaymentLinkType = FixedPrice
+	b.req.LocalAmount = amount
+	b.req.LocalCurrency = currency
+	return b
+}
+
+// Donation lets the customer choose the amount, in currency.
+func (b *PaymentLinkBuilder) Donation(currency string) *PaymentLinkBuilder {
+	b.req.PaymentLinkType = Donation
+	b.req.LocalAmount = 0
+	b.req.LocalCurrency = currency
+	return b
+}
+
+// RequestInfo adds the information requested from the customer.
+func (b *PaymentLinkBuilder) RequestInfo(fields ...RequestedInfoField) *PaymentLinkBuilder {
+	b.req.RequestedInfo = append(b.req.RequestedInfo, fields...)
+	return b
+}
+
+// Build returns the request, or the validation error.
+func (b *PaymentLinkBuilder) Build() (*PaymentLinkRequest, error) {
+	req := b.req
+	req.RequestedInfo = append([]RequestedInfoField(nil), b.req.RequestedInfo...)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
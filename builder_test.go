@@ -0,0 +1,60 @@
+package busha_commerce_go
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChargeBuilder(t *testing.T) {
+	type order struct {
+		ID    int    `json:"id"`
+		Email string `json:"email"`
+	}
+	req, err := NewCharge().
+		Fixed(5000, "NGN").
+		Reference("order-1234").
+		SuccessURL("https://shop.example.com/success").
+		CancelURL("https://shop.example.com/cancel").
+		Meta(order{ID: 1234, Email: "x@y.com"}).
+		Build()
+	assert.NoError(t, err)
+	assert.True(t, req.FixedPrice)
+	assert.Equal(t, 5000, req.LocalAmount)
+	assert.Equal(t, "order-1234", *req.Reference)
+	assert.Equal(t, "https://shop.example.com/success", *req.SuccessRedirectURL)
+	assert.JSONEq(t, `{"id":1234,"email":"x@y.com"}`, string(req.Meta))
+
+	_, err = NewCharge().Reference("abc").Build()
+	assertFieldErrors(t, err, []string{"reference"})
+
+	_, err = NewCharge().Meta(make(chan int)).Build()
+	assert.Error(t, err)
+}
+
+func TestInvoiceBuilder(t *testing.T) {
+	req, err := NewInvoice("Development services").
+		Customer("Astro", "syz@g.com").
+		Amount(5000, "NGN").
+		Description("Test description").
+		DueIn(24 * time.Hour).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "Astro", req.CustomerName)
+	assert.True(t, req.DueDate.After(time.Now()))
+
+	_, err = NewInvoice("Development services").Amount(5000, "NGN").Build()
+	assertFieldErrors(t, err, []string{"customer_email"})
+}
+
+func TestPaymentLinkBuilder(t *testing.T) {
+	req, err := NewPaymentLink("iPhone 14 Pro").Fixed(800, "NGN").RequestInfo("name", "email").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, FixedPrice, req.PaymentLinkType)
+	assert.Equal(t, []RequestedInfoField{RequestName, RequestEmail}, req.RequestedInfo)
+
+	req, err = NewPaymentLink("Astro NGO").Donation("NGN").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, Donation, req.PaymentLinkType)
+}
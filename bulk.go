@@ -0,0 +1,271 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BulkInvoiceRow is an invoice to create in bulk.
+type BulkInvoiceRow struct {
+	//Row is the 1-based position of the row in its source file
+	Row int
+	//Key identifies the row across runs. It defaults to a hash of the row
+	//number and request, so rows must keep their position when resuming.
+	Key     string
+	Request InvoiceRequest
+}
+
+// Bulk result statuses.
+const (
+	BulkCreated = "created"
+	BulkSkipped = "skipped"
+	BulkFailed  = "failed"
+)
+
+// BulkInvoiceResult is the outcome of one row.
+type BulkInvoiceResult struct {
+	Row       int
+	Key       string
+	Status    string
+	InvoiceID string
+	Reference string
+	Error     string
+}
+
+// BulkInvoiceReport lists the outcome of every row, ordered by row.
+type BulkInvoiceReport struct {
+	Results []BulkInvoiceResult
+}
+
+// BulkOptions configures InvoiceService.CreateBulk.
+type BulkOptions struct {
+	//Concurrency is the number of invoices created at once, defaults to 4
+	Concurrency int
+	//Previous is the report of an earlier run. Rows it created are skipped.
+	Previous *BulkInvoiceReport
+}
+
+var bulkCSVHeader = []string{"row", "key", "status", "invoice_id", "reference", "error"}
+
+// ReadInvoiceCSV reads invoice rows from CSV with a header naming the
+// columns: key, name, customer_email, customer_name, local_amount,
+// local_currency, description and due_date (RFC 3339 or YYYY-MM-DD).
+func ReadInvoiceCSV(r io.Reader) ([]BulkInvoiceRow, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("csv has no header")
+	}
+	cols := make(map[string]int)
+	for i, h := range records[0] {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	get := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	rows := make([]BulkInvoiceRow, 0, len(records)-1)
+	for n, rec := range records[1:] {
+		row := BulkInvoiceRow{
+			Row: n + 1,
+			Key: get(rec, "key"),
+			Request: InvoiceRequest{
+				Name:          get(rec, "name"),
+				CustomerEmail: get(rec, "customer_email"),
+				CustomerName:  get(rec, "customer_name"),
+				LocalCurrency: get(rec, "local_currency"),
+				Description:   get(rec, "description"),
+			},
+		}
+		if v := get(rec, "local_amount"); v != "" {
+			if row.Request.LocalAmount, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("row %d: invalid local_amount %q", row.Row, v)
+			}
+		}
+		if v := get(rec, "due_date"); v != "" {
+			due, err := parseDueDate(v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid due_date %q", row.Row, v)
+			}
+			row.Request.DueDate = &due
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseDueDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+// ReadInvoiceJSON reads invoice rows from a JSON array of InvoiceRequest
+// objects, each with an optional "key".
+func ReadInvoiceJSON(r io.Reader) ([]BulkInvoiceRow, error) {
+	var items []struct {
+		Key string `json:"key"`
+		InvoiceRequest
+	}
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, err
+	}
+	rows := make([]BulkInvoiceRow, len(items))
+	for i, item := range items {
+		rows[i] = BulkInvoiceRow{Row: i + 1, Key: item.Key, Request: item.InvoiceRequest}
+	}
+	return rows, nil
+}
+
+// key returns Key, or a hash of the row number and request so identical
+// rows, i.e. the same customer billed twice, are still created separately.
+func (r BulkInvoiceRow) key() string {
+	if r.Key != "" {
+		return r.Key
+	}
+	b, _ := json.Marshal(struct {
+		Row     int            `json:"row"`
+		Request InvoiceRequest `json:"request"`
+	}{r.Row, r.Request})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:12])
+}
+
+func (s *InvoiceService) CreateBulk(rows []BulkInvoiceRow, opts BulkOptions) (*BulkInvoiceReport, error) {
+	return s.CreateBulkWithContext(context.Background(), rows, opts)
+}
+
+// CreateBulkWithContext validates and creates rows with bounded concurrency.
+// Rows already created in opts.Previous are skipped, so a failed run can be
+// resumed by passing its report. Each request carries its row key as
+// idempotency key. Row failures are recorded in the report; the returned
+// error is only set when ctx is done.
+func (s *InvoiceService) CreateBulkWithContext(ctx context.Context, rows []BulkInvoiceRow, opts BulkOptions) (*BulkInvoiceReport, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	done := make(map[string]BulkInvoiceResult)
+	if opts.Previous != nil {
+		for _, r := range opts.Previous.Results {
+			if r.Status == BulkCreated || r.Status == BulkSkipped {
+				done[r.Key] = r
+			}
+		}
+	}
+
+	// Record skipped and invalid rows first so a cancelled run still reports
+	// rows created by an earlier run.
+	results := make([]BulkInvoiceResult, len(rows))
+	var pending []int
+	for i, row := range rows {
+		key := row.key()
+		if prev, ok := done[key]; ok {
+			results[i] = BulkInvoiceResult{Row: row.Row, Key: key, Status: BulkSkipped, InvoiceID: prev.InvoiceID, Reference: prev.Reference}
+			continue
+		}
+		if err := s.client.validate(&row.Request); err != nil {
+			results[i] = BulkInvoiceResult{Row: row.Row, Key: key, Status: BulkFailed, Error: err.Error()}
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for n, i := range pending {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for _, j := range pending[n:] {
+				results[j] = BulkInvoiceResult{Row: rows[j].Row, Key: rows[j].key(), Status: BulkFailed, Error: ctx.Err().Error()}
+			}
+			wg.Wait()
+			return newBulkReport(results), ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, row BulkInvoiceRow) {
+			defer func() { <-sem; wg.Done() }()
+			key := row.key()
+			req := row.Request
+			resp, err := s.CreateWithContext(WithIdempotencyKey(ctx, key), &req)
+			if err != nil {
+				results[i] = BulkInvoiceResult{Row: row.Row, Key: key, Status: BulkFailed, Error: err.Error()}
+				return
+			}
+			results[i] = BulkInvoiceResult{Row: row.Row, Key: key, Status: BulkCreated, InvoiceID: resp.Data.Id.String(), Reference: resp.Data.Reference}
+		}(i, rows[i])
+	}
+	wg.Wait()
+	return newBulkReport(results), ctx.Err()
+}
+
+func newBulkReport(results []BulkInvoiceResult) *BulkInvoiceReport {
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Row < results[j].Row })
+	return &BulkInvoiceReport{Results: results}
+}
+
+// Failed returns the rows that were not created.
+func (r *BulkInvoiceReport) Failed() []BulkInvoiceResult {
+	var out []BulkInvoiceResult
+	for _, res := range r.Results {
+		if res.Status == BulkFailed {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// WriteCSV writes the report as CSV, readable by ReadBulkInvoiceReport.
+func (r *BulkInvoiceReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(bulkCSVHeader); err != nil {
+		return err
+	}
+	for _, res := range r.Results {
+		if err := cw.Write([]string{strconv.Itoa(res.Row), res.Key, res.Status, res.InvoiceID, res.Reference, res.Error}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadBulkInvoiceReport reads a report written by WriteCSV.
+func ReadBulkInvoiceReport(r io.Reader) (*BulkInvoiceReport, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	report := &BulkInvoiceReport{}
+	for i, rec := range records {
+		if i == 0 || len(rec) != len(bulkCSVHeader) {
+			continue
+		}
+		row, err := strconv.Atoi(rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("report line %d: invalid row %q", i+1, rec[0])
+		}
+		report.Results = append(report.Results, BulkInvoiceResult{
+			Row: row, Key: rec[1], Status: rec[2], InvoiceID: rec[3], Reference: rec[4], Error: rec[5],
+		})
+	}
+	return report, nil
+}
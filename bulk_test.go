@@ -0,0 +1,149 @@
+package busha_commerce_go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testInvoiceCSV = `key,name,customer_email,customer_name,local_amount,local_currency,description,due_date
+inv-1,Services,a@example.com,A,5000,NGN,January,
+inv-2,Services,not-an-email,B,5000,NGN,January,
+inv-3,Services,c@example.com,C,7500,NGN,January,2099-01-31
+`
+
+func TestReadInvoiceCSV(t *testing.T) {
+	rows, err := ReadInvoiceCSV(strings.NewReader(testInvoiceCSV))
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 3) {
+		assert.Equal(t, "inv-1", rows[0].Key)
+		assert.Equal(t, 5000.0, rows[0].Request.LocalAmount)
+		assert.Nil(t, rows[0].Request.DueDate)
+		assert.Equal(t, 2099, rows[2].Request.DueDate.Year())
+	}
+
+	_, err = ReadInvoiceCSV(strings.NewReader("name,local_amount\nx,abc\n"))
+	assert.Error(t, err)
+}
+
+func TestReadInvoiceJSON(t *testing.T) {
+	rows, err := ReadInvoiceJSON(strings.NewReader(`[{"key":"k1","name":"Services","customer_email":"a@example.com","local_amount":"5000","local_currency":"NGN"}]`))
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, "k1", rows[0].Key)
+		assert.Equal(t, 5000.0, rows[0].Request.LocalAmount)
+	}
+}
+
+func TestInvoiceService_CreateBulk(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	fail := true
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := r.Header.Get("Idempotency-Key")
+		if key == "inv-3" && fail {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error":{"name":"BadGateway","message":"Bad gateway"}}`))
+			return
+		}
+		keys = append(keys, key)
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"id":"11111111-1111-1111-1111-11111111111%d","reference":"ref-%s"}}`, len(keys), key)
+	})
+
+	rows, err := ReadInvoiceCSV(strings.NewReader(testInvoiceCSV))
+	assert.NoError(t, err)
+
+	report, err := client.Invoice.CreateBulk(rows, BulkOptions{Concurrency: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{BulkCreated, BulkFailed, BulkFailed}, []string{report.Results[0].Status, report.Results[1].Status, report.Results[2].Status})
+	assert.Equal(t, "ref-inv-1", report.Results[0].Reference)
+	assert.Len(t, report.Failed(), 2)
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&buf))
+	previous, err := ReadBulkInvoiceReport(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, report, previous)
+
+	fail = false
+	report, err = client.Invoice.CreateBulk(rows, BulkOptions{Previous: previous})
+	assert.NoError(t, err)
+	assert.Equal(t, BulkSkipped, report.Results[0].Status)
+	assert.Equal(t, "ref-inv-1", report.Results[0].Reference)
+	assert.Equal(t, BulkFailed, report.Results[1].Status)
+	assert.Equal(t, BulkCreated, report.Results[2].Status)
+	assert.Equal(t, []string{"inv-1", "inv-3"}, keys)
+}
+
+func TestInvoiceService_CreateBulkDuplicateRows(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string]bool)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys[r.Header.Get("Idempotency-Key")] = true
+		_, _ = w.Write([]byte(`{"status":"success","data":{"id":"11111111-1111-1111-1111-111111111111"}}`))
+	})
+
+	// The same customer billed twice, without a key column.
+	req := InvoiceRequest{Name: "Services", CustomerEmail: "a@example.com", LocalAmount: 5000, LocalCurrency: "NGN"}
+	rows := []BulkInvoiceRow{{Row: 1, Request: req}, {Row: 2, Request: req}}
+
+	report, err := client.Invoice.CreateBulk(rows, BulkOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, BulkCreated, report.Results[0].Status)
+	assert.Equal(t, BulkCreated, report.Results[1].Status)
+	assert.NotEqual(t, report.Results[0].Key, report.Results[1].Key)
+	assert.Len(t, keys, 2)
+}
+
+func TestInvoiceService_CreateBulkWithoutValidation(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"id":"11111111-1111-1111-1111-111111111111"}}`))
+	})
+	client.SetValidation(false)
+
+	report, err := client.Invoice.CreateBulk([]BulkInvoiceRow{{Row: 1, Request: InvoiceRequest{Name: "Services"}}}, BulkOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, BulkCreated, report.Results[0].Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestInvoiceService_CreateBulkCancelledResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	var keys []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	req := InvoiceRequest{Name: "Services", CustomerEmail: "a@example.com", LocalAmount: 5000, LocalCurrency: "NGN"}
+	rows := []BulkInvoiceRow{{Row: 1, Key: "a", Request: req}, {Row: 2, Key: "b", Request: req}, {Row: 3, Key: "c", Request: req}}
+	previous := &BulkInvoiceReport{Results: []BulkInvoiceResult{
+		{Row: 1, Key: "a", Status: BulkFailed, Error: "Bad gateway"},
+		{Row: 2, Key: "b", Status: BulkFailed, Error: "Bad gateway"},
+		{Row: 3, Key: "c", Status: BulkCreated, InvoiceID: "inv-c", Reference: "ref-c"},
+	}}
+
+	report, err := client.Invoice.CreateBulkWithContext(ctx, rows, BulkOptions{Concurrency: 1, Previous: previous})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, BulkFailed, report.Results[1].Status)
+	assert.Equal(t, BulkInvoiceResult{Row: 3, Key: "c", Status: BulkSkipped, InvoiceID: "inv-c", Reference: "ref-c"}, report.Results[2])
+	assert.Equal(t, []string{"a"}, keys)
+}
@@ -1,6 +1,8 @@
 package busha_commerce_go
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/gobuffalo/uuid"
@@ -10,6 +12,17 @@ import (
 
 type ChargeService service
 
+// Charge timeline statuses.
+const (
+	ChargeStatusNew        = "NEW"
+	ChargeStatusPending    = "PENDING"
+	ChargeStatusCompleted  = "COMPLETED"
+	ChargeStatusExpired    = "EXPIRED"
+	ChargeStatusUnresolved = "UNRESOLVED"
+	ChargeStatusResolved   = "RESOLVED"
+	ChargeStatusCanceled   = "CANCELED"
+)
+
 type Charge struct {
 	Id               uuid.UUID               `json:"id"`
 	BusinessId       uuid.UUID               `json:"business_id"`
@@ -17,7 +30,7 @@ type Charge struct {
 	Reference        string                  `json:"reference"`
 	HostedUrl        string                  `json:"hosted_url"`
 	PriceFixed       bool                    `json:"price_fixed"`
-	Meta             map[string]interface{}  `json:"meta"`
+	Meta             json.RawMessage         `json:"meta"`
 	ExpiresAt        time.Time               `json:"expires_at"`
 	Timeline         []ChargeTimeline        `json:"timeline"`
 	SupportedAssets  []ChargeSupportedAssets `json:"supported_assets"`
@@ -28,6 +41,27 @@ type Charge struct {
 	CallbackUrl      string                  `json:"callback_url"`
 	LocalAmount      float64                 `json:"local_amount,string"`
 	LocalCurrency    string                  `json:"local_currency"`
+	Type             *string                 `json:"type,omitempty"`
+	ResourceID       *uuid.UUID              `json:"resource_id,omitempty"`
+}
+
+// Status returns the status of the latest timeline entry.
+func (c *Charge) Status() string {
+	var latest *ChargeTimeline
+	for i := range c.Timeline {
+		if latest == nil || !c.Timeline[i].CreatedAt.Before(latest.CreatedAt) {
+			latest = &c.Timeline[i]
+		}
+	}
+	if latest == nil {
+		return ChargeStatusNew
+	}
+	return strings.ToUpper(latest.Status)
+}
+
+// IsOpen reports whether the charge is still waiting for a payment.
+func (c *Charge) IsOpen() bool {
+	return c.Status() == ChargeStatusNew
 }
 
 type ChargeAddress struct {
@@ -93,46 +127,69 @@ type ListChargesResponse struct {
 }
 
 func (s *ChargeService) Create(req *ChargeRequest) (*ChargeResponse, error) {
+	return s.CreateWithContext(context.Background(), req)
+}
+
+func (s *ChargeService) CreateWithContext(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
 	var resp = new(ChargeResponse)
-	err := s.client.call("POST", "/charges", req, &resp)
+	if err := s.client.validate(req); err != nil {
+		return resp, err
+	}
+	err := s.client.call(ctx, OpChargeCreate, "POST", "/charges", req, &resp)
 	return resp, err
 }
 
 func (s *ChargeService) List(params ListParameters) (*ListChargesResponse, error) {
+	return s.ListWithContext(context.Background(), params)
+}
+
+func (s *ChargeService) ListWithContext(ctx context.Context, params ListParameters) (*ListChargesResponse, error) {
 	var resp = new(ListChargesResponse)
-	err := s.client.call("GET", fmt.Sprintf("/charges?sort=%s&limit=%d&page=%d",
+	err := s.client.call(ctx, OpChargeList, "GET", fmt.Sprintf("/charges?sort=%s&limit=%d&page=%d",
 		params.Sort, params.Limit, params.Page), params, &resp)
 	return resp, err
 }
 
 func (s *ChargeService) Get(id string) (*ChargeResponse, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+func (s *ChargeService) GetWithContext(ctx context.Context, id string) (*ChargeResponse, error) {
 	var resp = new(ChargeResponse)
 	if id == "" {
 		return nil, errors.New("no chargeID provided")
 	}
-	err := s.client.call("GET", fmt.Sprintf("/charges/%s", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpChargeGet, "GET", fmt.Sprintf("/charges/%s", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
 
-func (s *ChargeService) Resolve(id, context string) (*ChargeResponse, error) {
+func (s *ChargeService) Resolve(id, resolution string) (*ChargeResponse, error) {
+	return s.ResolveWithContext(context.Background(), id, resolution)
+}
+
+func (s *ChargeService) ResolveWithContext(ctx context.Context, id, resolution string) (*ChargeResponse, error) {
 	req := struct {
 		Context string `json:"context"`
 	}{
-		Context: context,
+		Context: resolution,
 	}
 	var resp = new(ChargeResponse)
 	if id == "" {
 		return nil, errors.New("please provide a chargeID")
 	}
-	err := s.client.call("POST", fmt.Sprintf("/charges/%s/resolve", strings.TrimSpace(id)), req, &resp)
+	err := s.client.call(ctx, OpChargeResolve, "POST", fmt.Sprintf("/charges/%s/resolve", strings.TrimSpace(id)), req, &resp)
 	return resp, err
 }
 
 func (s *ChargeService) Cancel(id string) (*ChargeResponse, error) {
+	return s.CancelWithContext(context.Background(), id)
+}
+
+func (s *ChargeService) CancelWithContext(ctx context.Context, id string) (*ChargeResponse, error) {
 	var resp = new(ChargeResponse)
 	if id == "" {
 		return resp, errors.New("please provide a chargeID")
 	}
-	err := s.client.call("PUT", fmt.Sprintf("/charges/%s/cancel", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpChargeCancel, "PUT", fmt.Sprintf("/charges/%s/cancel", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
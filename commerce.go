@@ -8,6 +8,7 @@ import (
 	"github.com/joho/godotenv"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -28,11 +29,11 @@ type service struct {
 }
 
 type Client struct {
-	base      service
-	client    *http.Client
-	secretKey string
-	userAgent string
-	baseURL   *url.URL
+	base        service
+	client      *http.Client
+	credentials CredentialProvider
+	userAgent   string
+	baseURL     *url.URL
 
 	LogDebug bool
 	Log      Logger
@@ -42,6 +43,17 @@ type Client struct {
 	Invoice     *InvoiceService
 	Event       *EventService
 	Address     *AddressService
+
+	logger       *slog.Logger
+	redactFields map[string]bool
+	middleware   []Middleware
+	breaker      *CircuitBreaker
+	environment  Environment
+
+	skipValidation bool
+	resolutionLog  ResolutionLog
+	rateLimiter    *RateLimiter
+	rateLimiters   map[EndpointGroup]*RateLimiter
 }
 
 type Logger interface {
@@ -71,6 +83,16 @@ func New(key string, httpClient *http.Client) (*Client, error) {
 	if err := validateKey(key); err != nil {
 		return nil, err
 	}
+	return NewWithCredentials(StaticCredentials(key), httpClient)
+}
+
+// NewWithCredentials returns a Client that asks p for the secret key on every
+// request. When p is an EnvironmentProvider, keys whose mode is not allowed in
+// its environment are refused.
+func NewWithCredentials(p CredentialProvider, httpClient *http.Client) (*Client, error) {
+	if p == nil {
+		return nil, errors.New("commerce credential provider cannot be nil")
+	}
 
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
@@ -78,12 +100,12 @@ func New(key string, httpClient *http.Client) (*Client, error) {
 
 	u, _ := url.Parse(baseURL)
 	c := &Client{
-		client:    httpClient,
-		secretKey: key,
-		userAgent: userAgent,
-		baseURL:   u,
-		LogDebug:  false,
-		Log:       log.New(os.Stderr, "", log.LstdFlags),
+		client:      httpClient,
+		credentials: p,
+		userAgent:   userAgent,
+		baseURL:     u,
+		LogDebug:    false,
+		Log:         log.New(os.Stderr, "", log.LstdFlags),
 	}
 
 	c.base.client = c
@@ -92,56 +114,117 @@ func New(key string, httpClient *http.Client) (*Client, error) {
 	c.Invoice = (*InvoiceService)(&c.base)
 	c.Event = (*EventService)(&c.base)
 	c.Address = (*AddressService)(&c.base)
+	if ep, ok := p.(EnvironmentProvider); ok {
+		c.environment = ep.Environment()
+	}
+
+	key, err := c.secretKey(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkMode(key); err != nil {
+		return nil, err
+	}
 
 	return c, nil
 }
 
-func (c *Client) call(method, path string, reqBody, response interface{}) (err error) {
-	buffer := bytes.NewBuffer([]byte{})
-	if method == http.MethodPost || method == http.MethodPut {
-		if err = json.NewEncoder(buffer).Encode(reqBody); err != nil {
-			return err
-		}
-	}
-	u, _ := c.baseURL.Parse(path)
-	req, err := http.NewRequest(method, u.String(), buffer)
-
+func (c *Client) call(ctx context.Context, op, method, path string, reqBody, response interface{}) error {
+	key, err := c.secretKey(ctx)
 	if err != nil {
 		return err
 	}
+	if err = c.checkMode(key); err != nil {
+		return err
+	}
+	mode := modeOf(key)
+
+	req := &Request{
+		Operation:  op,
+		Method:     method,
+		Path:       path,
+		ResourceID: resourceID(path),
+		Body:       reqBody,
+		Header:     make(http.Header),
+		Response:   response,
+		Mode:       mode,
+		secretKey:  key,
+	}
+	if k, ok := ctx.Value(idempotencyKey{}).(string); ok && k != "" {
+		req.Header.Set("Idempotency-Key", k)
+	}
+	_, err = c.handler()(ctx, req)
+	return err
+}
 
-	req.Header.Set("X-BC-API-KEY", c.secretKey)
-	req.Header.Add("User-Agent", c.userAgent)
-	req.Header.Set("Content-Type", "application/json")
-
-	if c.LogDebug {
-		c.Log.Printf("Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
-		c.Log.Printf("%s request data %v\n", req.Method, reqBody)
+func (c *Client) send(ctx context.Context, r *Request) (res *Result, err error) {
+	var body []byte
+	if r.Method == http.MethodPost || r.Method == http.MethodPut || (r.Method == http.MethodPatch && r.Body != nil) {
+		if body, err = json.Marshal(r.Body); err != nil {
+			return nil, err
+		}
 	}
+	u, _ := c.baseURL.Parse(r.Path)
+	limiter := c.limiterFor(r.Path)
+
+	res = new(Result)
+	for ; ; res.Retries++ {
+		if limiter != nil {
+			if err = limiter.Wait(ctx); err != nil {
+				return res, err
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, r.Method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			return res, err
+		}
 
-	req = req.WithContext(context.TODO())
+		for k, v := range r.Header {
+			req.Header[k] = v
+		}
+		req.Header.Set("X-BC-API-KEY", r.secretKey)
+		req.Header.Add("User-Agent", c.userAgent)
+		req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return res, err
+		}
+		res.StatusCode, res.Header = resp.StatusCode, resp.Header
+
+		if limiter != nil {
+			limiter.Observe(resp.StatusCode, resp.Header)
+			if resp.StatusCode == http.StatusTooManyRequests && res.Retries < limiter.MaxRetries {
+				_ = resp.Body.Close()
+				continue
+			}
+		}
+
+		err = decodeResponse(resp, r.Response)
+		if e, ok := err.(ErrResponse); ok {
+			e.Mode = r.Mode
+			err = e
+		}
+		return res, err
 	}
+}
+
+func decodeResponse(resp *http.Response, response interface{}) error {
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
 	}(resp.Body)
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		e := ErrResponse{}
-		err = json.NewDecoder(resp.Body).Decode(&e)
-
-		if err != nil {
+		e := ErrResponse{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
 			return err
 		}
 
 		return e
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(response)
-	return
+	return json.NewDecoder(resp.Body).Decode(response)
 }
 
 func (c *Client) SetDebug(debug bool) {
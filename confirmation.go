@@ -0,0 +1,192 @@
+package busha_commerce_go
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultExplorers maps a chain to the URL template of its block explorer.
+var DefaultExplorers = map[string]string{
+	"BTC":     "https://mempool.space/tx/%s",
+	"ETH":     "https://etherscan.io/tx/%s",
+	"TRX":     "https://tronscan.org/#/transaction/%s",
+	"BSC":     "https://bscscan.com/tx/%s",
+	"MATIC":   "https://polygonscan.com/tx/%s",
+	"POLYGON": "https://polygonscan.com/tx/%s",
+	"SOL":     "https://solscan.io/tx/%s",
+	"LTC":     "https://blockchair.com/litecoin/transaction/%s",
+	"DOGE":    "https://blockchair.com/dogecoin/transaction/%s",
+	"BCH":     "https://blockchair.com/bitcoin-cash/transaction/%s",
+}
+
+// ExplorerURL returns the block explorer link of a transaction on chain,
+// or an empty string for unknown chains.
+func ExplorerURL(chain, hash string) string {
+	tmpl, ok := DefaultExplorers[strings.ToUpper(chain)]
+	if !ok || hash == "" {
+		return ""
+	}
+	return fmt.Sprintf(tmpl, hash)
+}
+
+// ConfirmationPolicy sets how many confirmations a payment needs on each
+// chain before it is considered final.
+type ConfirmationPolicy struct {
+	//Required maps a chain i.e. "BTC" to the confirmations it needs
+	Required map[string]int
+	//Default applies to chains missing from Required
+	Default int
+}
+
+// DefaultConfirmationPolicy is a conservative policy for common chains.
+var DefaultConfirmationPolicy = ConfirmationPolicy{
+	Required: map[string]int{
+		"BTC": 3,
+		"ETH": 12,
+		"TRX": 20,
+		"BSC": 15,
+		"LTC": 6,
+	},
+	Default: 6,
+}
+
+// RequiredFor returns the confirmations needed on chain.
+func (p ConfirmationPolicy) RequiredFor(chain string) int {
+	if n, ok := p.Required[strings.ToUpper(chain)]; ok {
+		return n
+	}
+	return p.Default
+}
+
+// IsFinal reports whether payment has enough confirmations.
+func (p ConfirmationPolicy) IsFinal(payment ChargePayment) bool {
+	return payment.Confirmation >= p.RequiredFor(payment.Chain)
+}
+
+// PaymentStage is how far a payment has progressed under a ConfirmationPolicy.
+type PaymentStage int
+
+const (
+	PaymentDetected PaymentStage = iota
+	PaymentConfirming
+	PaymentFinal
+)
+
+func (s PaymentStage) String() string {
+	switch s {
+	case PaymentDetected:
+		return "detected"
+	case PaymentConfirming:
+		return "confirming"
+	case PaymentFinal:
+		return "final"
+	default:
+		return fmt.Sprintf("PaymentStage(%d)", int(s))
+	}
+}
+
+// PaymentEvent is emitted by a ConfirmationTracker when a payment progresses.
+type PaymentEvent struct {
+	ChargeID      string
+	Payment       ChargePayment
+	Stage         PaymentStage
+	Confirmations int
+	Required      int
+	//ExplorerURL is the payment's BlockUrl, or a link derived from its chain and hash
+	ExplorerURL string
+}
+
+// ConfirmationTracker follows the payments of charges as they are polled or
+// received through events, and emits a PaymentEvent whenever a payment is
+// detected, gains confirmations or becomes final.
+type ConfirmationTracker struct {
+	Policy ConfirmationPolicy
+	//OnEvent is called for every event, in addition to them being returned by Observe
+	OnEvent func(PaymentEvent)
+
+	mu       sync.Mutex
+	payments map[string]trackedPayment
+}
+
+type trackedPayment struct {
+	stage         PaymentStage
+	confirmations int
+}
+
+// NewConfirmationTracker returns a tracker applying policy.
+func NewConfirmationTracker(policy ConfirmationPolicy) *ConfirmationTracker {
+	return &ConfirmationTracker{Policy: policy, payments: make(map[string]trackedPayment)}
+}
+
+// Observe records the payments of a charge and returns the events they produce.
+func (t *ConfirmationTracker) Observe(c *Charge) []PaymentEvent {
+	return t.observe(c.Id.String(), c.Payments)
+}
+
+// ObserveEvent records the payments carried by a webhook or polled event.
+func (t *ConfirmationTracker) ObserveEvent(e *Event) []PaymentEvent {
+	return t.observe(e.Data.Id.String(), e.Data.Payment)
+}
+
+func (t *ConfirmationTracker) observe(chargeID string, payments []ChargePayment) []PaymentEvent {
+	t.mu.Lock()
+	var events []PaymentEvent
+	for _, p := range payments {
+		key := paymentKey(chargeID, p)
+		required := t.Policy.RequiredFor(p.Chain)
+		stage := PaymentConfirming
+		switch {
+		case p.Confirmation >= required:
+			stage = PaymentFinal
+		case p.Confirmation == 0:
+			stage = PaymentDetected
+		}
+
+		prev, seen := t.payments[key]
+		if seen && (prev.stage == PaymentFinal || (prev.stage == stage && prev.confirmations == p.Confirmation)) {
+			continue
+		}
+		t.payments[key] = trackedPayment{stage: stage, confirmations: p.Confirmation}
+
+		explorer := p.BlockUrl
+		if explorer == "" {
+			explorer = ExplorerURL(p.Chain, p.TransactionHash)
+		}
+		events = append(events, PaymentEvent{
+			ChargeID:      chargeID,
+			Payment:       p,
+			Stage:         stage,
+			Confirmations: p.Confirmation,
+			Required:      required,
+			ExplorerURL:   explorer,
+		})
+	}
+	t.mu.Unlock()
+
+	if t.OnEvent != nil {
+		for _, e := range events {
+			t.OnEvent(e)
+		}
+	}
+	return events
+}
+
+// Forget drops the state kept for the payments of a charge.
+func (t *ConfirmationTracker) Forget(chargeID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key := range t.payments {
+		if strings.HasPrefix(key, chargeID+"/") {
+			delete(t.payments, key)
+		}
+	}
+}
+
+func paymentKey(chargeID string, p ChargePayment) string {
+	id := p.TransactionHash
+	if id == "" {
+		id = p.TransactionId
+	}
+	return chargeID + "/" + strings.ToUpper(p.Chain) + "/" + id
+}
@@ -0,0 +1,45 @@
+package busha_commerce_go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmationTracker(t *testing.T) {
+	var emitted []PaymentStage
+	tracker := NewConfirmationTracker(ConfirmationPolicy{Required: map[string]int{"BTC": 2}, Default: 1})
+	tracker.OnEvent = func(e PaymentEvent) { emitted = append(emitted, e.Stage) }
+
+	charge := &Charge{Payments: []ChargePayment{{Chain: "BTC", TransactionHash: "abc", Confirmation: 0}}}
+	events := tracker.Observe(charge)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, PaymentDetected, events[0].Stage)
+		assert.Equal(t, 2, events[0].Required)
+		assert.Equal(t, "https://mempool.space/tx/abc", events[0].ExplorerURL)
+	}
+	assert.Empty(t, tracker.Observe(charge))
+
+	charge.Payments[0].Confirmation = 1
+	charge.Payments[0].BlockUrl = "https://explorer.example.com/abc"
+	events = tracker.Observe(charge)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, PaymentConfirming, events[0].Stage)
+		assert.Equal(t, "https://explorer.example.com/abc", events[0].ExplorerURL)
+	}
+
+	charge.Payments[0].Confirmation = 3
+	charge.Payments = append(charge.Payments, ChargePayment{Chain: "TRX", TransactionHash: "def", Confirmation: 1})
+	events = tracker.Observe(charge)
+	assert.Len(t, events, 2)
+
+	charge.Payments[0].Confirmation = 4
+	assert.Empty(t, tracker.Observe(charge))
+	assert.Equal(t, []PaymentStage{PaymentDetected, PaymentConfirming, PaymentFinal, PaymentFinal}, emitted)
+}
+
+func TestExplorerURL(t *testing.T) {
+	assert.Equal(t, "https://etherscan.io/tx/0x1", ExplorerURL("eth", "0x1"))
+	assert.Equal(t, "", ExplorerURL("UNKNOWN", "0x1"))
+	assert.Equal(t, "", ExplorerURL("ETH", ""))
+}
@@ -0,0 +1,124 @@
+package busha_commerce_go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the secret key sent as X-BC-API-KEY.
+// It is consulted on every request, so keys can be rotated without
+// recreating the Client.
+type CredentialProvider interface {
+	SecretKey(ctx context.Context) (string, error)
+}
+
+// StaticCredentials is a fixed secret key.
+type StaticCredentials string
+
+func (s StaticCredentials) SecretKey(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// EnvCredentials reads the secret key from an environment variable on every request.
+type EnvCredentials string
+
+func (e EnvCredentials) SecretKey(context.Context) (string, error) {
+	key, ok := os.LookupEnv(string(e))
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", string(e))
+	}
+	return strings.TrimSpace(key), nil
+}
+
+// FileCredentials reads the secret key from a file, reloading it whenever
+// the file's modification time changes.
+type FileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+}
+
+// NewFileCredentials returns a FileCredentials reading from path.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+func (f *FileCredentials) SecretKey(context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.key != "" && info.ModTime().Equal(f.modTime) {
+		return f.key, nil
+	}
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+	f.key, f.modTime = strings.TrimSpace(string(b)), info.ModTime()
+	return f.key, nil
+}
+
+// ExecCredentials runs a command and uses its trimmed output as the secret
+// key, i.e. a secret manager CLI. The key is cached for TTL.
+type ExecCredentials struct {
+	Command string
+	Args    []string
+	//TTL is how long a key is reused before running the command again
+	TTL time.Duration
+
+	mu        sync.Mutex
+	key       string
+	fetchedAt time.Time
+	now       func() time.Time
+}
+
+// NewExecCredentials returns an ExecCredentials running command with args.
+func NewExecCredentials(ttl time.Duration, command string, args ...string) *ExecCredentials {
+	return &ExecCredentials{Command: command, Args: args, TTL: ttl, now: time.Now}
+}
+
+func (e *ExecCredentials) SecretKey(ctx context.Context) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.now == nil {
+		e.now = time.Now
+	}
+	if e.key != "" && e.now().Sub(e.fetchedAt) < e.TTL {
+		return e.key, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running %s: %w: %s", e.Command, err, strings.TrimSpace(stderr.String()))
+	}
+	e.key, e.fetchedAt = strings.TrimSpace(string(out)), e.now()
+	return e.key, nil
+}
+
+// secretKey fetches and validates the current key.
+func (c *Client) secretKey(ctx context.Context) (string, error) {
+	key, err := c.credentials.SecretKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err = validateKey(key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
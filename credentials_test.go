@@ -0,0 +1,100 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	assert.NoError(t, os.WriteFile(path, []byte("test_first\n"), 0o600))
+
+	creds := NewFileCredentials(path)
+	key, err := creds.SecretKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "test_first", key)
+
+	assert.NoError(t, os.WriteFile(path, []byte("test_second"), 0o600))
+	assert.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	key, err = creds.SecretKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "test_second", key)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("BUSHA_TEST_KEY", "test_env")
+	key, err := EnvCredentials("BUSHA_TEST_KEY").SecretKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "test_env", key)
+
+	_, err = EnvCredentials("BUSHA_MISSING_KEY").SecretKey(context.Background())
+	assert.Error(t, err)
+}
+
+func TestExecCredentials(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	creds := NewExecCredentials(time.Minute, "echo", "test_exec")
+	creds.now = func() time.Time { return now }
+
+	key, err := creds.SecretKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "test_exec", key)
+
+	creds.Args = []string{"test_rotated"}
+	key, _ = creds.SecretKey(context.Background())
+	assert.Equal(t, "test_exec", key)
+
+	now = now.Add(time.Minute)
+	key, _ = creds.SecretKey(context.Background())
+	assert.Equal(t, "test_rotated", key)
+}
+
+func TestNewWithCredentials_Rotation(t *testing.T) {
+	var got []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-BC-API-KEY"))
+		_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+	})
+	t.Setenv("BUSHA_ROTATED_KEY", "test_one")
+	client.credentials = EnvCredentials("BUSHA_ROTATED_KEY")
+
+	_, err := client.Charge.Get("id")
+	assert.NoError(t, err)
+	t.Setenv("BUSHA_ROTATED_KEY", "test_two")
+	_, err = client.Charge.Get("id")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test_one", "test_two"}, got)
+
+	_, err = NewWithCredentials(StaticCredentials("bad"), nil)
+	assert.Error(t, err)
+}
+
+func TestNewWithCredentials_Environment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("BUSHA_ROTATED_KEY", "test_one")
+	client, err := NewWithCredentials(ForEnvironment(Development, EnvCredentials("BUSHA_ROTATED_KEY")), srv.Client())
+	assert.NoError(t, err)
+	client.baseURL, _ = url.Parse(srv.URL)
+
+	_, err = client.Charge.Get("id")
+	assert.NoError(t, err)
+
+	t.Setenv("BUSHA_ROTATED_KEY", "live_two")
+	_, err = client.Charge.Get("id")
+	assert.Equal(t, ErrModeMismatch{Mode: LiveMode, Environment: Development}, err)
+
+	_, err = NewWithCredentials(ForEnvironment(Production, StaticCredentials("test_key")), nil)
+	assert.Equal(t, ErrModeMismatch{Mode: TestMode, Environment: Production}, err)
+}
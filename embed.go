@@ -0,0 +1,174 @@
+package busha_commerce_go
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gobuffalo/uuid"
+)
+
+// Checkout is a hosted checkout page that can be embedded in a storefront.
+type Checkout struct {
+	URL      string
+	Name     string
+	Amount   float64
+	Currency string
+}
+
+// CheckoutFromPaymentLink returns the checkout of a payment link.
+func CheckoutFromPaymentLink(l *PaymentLink) (Checkout, error) {
+	if l == nil {
+		return Checkout{}, errors.New("no payment link provided")
+	}
+	c := Checkout{URL: l.HostedUrl, Name: l.Name, Amount: l.LocalAmount, Currency: l.LocalCurrency}
+	return c, checkHostedURL(c.URL)
+}
+
+// CheckoutFromCharge returns the checkout of a charge.
+func CheckoutFromCharge(ch *Charge) (Checkout, error) {
+	if ch == nil {
+		return Checkout{}, errors.New("no charge provided")
+	}
+	c := Checkout{URL: ch.HostedUrl, Name: ch.BusinessName, Amount: ch.LocalAmount, Currency: ch.LocalCurrency}
+	return c, checkHostedURL(c.URL)
+}
+
+// checkHostedURL only lets absolute http(s) URLs into snippets and redirects.
+func checkHostedURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("hosted url %q is not an absolute http(s) URL", raw)
+	}
+	return nil
+}
+
+// EmbedStyle configures the snippets rendered by EmbedGenerator.
+type EmbedStyle struct {
+	//Label is the button text, defaults to "Pay with Busha"
+	Label string
+	//Class is added to the button for storefront CSS
+	Class string
+	//Background and Color are CSS colors of the button
+	Background string
+	Color      string
+	//Width and Height size the iframe, default to 100% and 640
+	Width  string
+	Height string
+}
+
+// DefaultEmbedStyle is used for fields left empty in the style passed to
+// NewEmbedGenerator.
+var DefaultEmbedStyle = EmbedStyle{
+	Label:      "Pay with Busha",
+	Background: "#0052ff",
+	Color:      "#ffffff",
+	Width:      "100%",
+	Height:     "640",
+}
+
+var (
+	defaultButtonTemplate = template.Must(template.New("button").Parse(
+		`<a href="{{.URL}}" class="{{.Style.Class}}" target="_blank" rel="noopener noreferrer" ` +
+			`style="display:inline-block;padding:12px 24px;border-radius:6px;text-decoration:none;` +
+			`background:{{.Style.Background}};color:{{.Style.Color}}">{{.Style.Label}}</a>`))
+	defaultIframeTemplate = template.Must(template.New("iframe").Parse(
+		`<iframe src="{{.URL}}" title="{{.Name}}" width="{{.Style.Width}}" height="{{.Style.Height}}" ` +
+			`style="border:0" allow="clipboard-write"></iframe>`))
+)
+
+// EmbedGenerator renders HTML snippets for checkouts. The templates are
+// executed with the Checkout fields and Style, and html/template escapes
+// every value for its context.
+type EmbedGenerator struct {
+	Button *template.Template
+	Iframe *template.Template
+	Style  EmbedStyle
+}
+
+// NewEmbedGenerator returns a generator using the default templates and
+// style, with the non-empty fields of style applied on top.
+func NewEmbedGenerator(style EmbedStyle) *EmbedGenerator {
+	s := DefaultEmbedStyle
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&s.Label, style.Label},
+		{&s.Class, style.Class},
+		{&s.Background, style.Background},
+		{&s.Color, style.Color},
+		{&s.Width, style.Width},
+		{&s.Height, style.Height},
+	} {
+		if f.src != "" {
+			*f.dst = f.src
+		}
+	}
+	return &EmbedGenerator{Button: defaultButtonTemplate, Iframe: defaultIframeTemplate, Style: s}
+}
+
+// ButtonHTML renders a checkout button.
+func (g *EmbedGenerator) ButtonHTML(c Checkout) (template.HTML, error) {
+	return g.render(g.Button, c)
+}
+
+// IframeHTML renders the checkout inside an iframe.
+func (g *EmbedGenerator) IframeHTML(c Checkout) (template.HTML, error) {
+	return g.render(g.Iframe, c)
+}
+
+func (g *EmbedGenerator) render(t *template.Template, c Checkout) (template.HTML, error) {
+	if err := checkHostedURL(c.URL); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	data := struct {
+		Checkout
+		Style EmbedStyle
+	}{c, g.Style}
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// RedirectHandler redirects requests to the checkout of the payment link
+// whose ID is the last segment of the request path, i.e. /pay/{id}.
+// Unknown links answer 404 and inactive ones 410 Gone, so storefronts can
+// share short URLs that stay valid when the hosted URL changes. Any other
+// API failure answers 502. Segments that are not UUIDs answer 404 without
+// calling the API.
+func (s *PaymentLinkService) RedirectHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.FromString(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		resp, err := s.GetWithContext(r.Context(), id.String())
+		if err != nil {
+			var e ErrResponse
+			if errors.As(err, &e) && e.StatusCode == http.StatusNotFound {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+		if !resp.Data.Active {
+			http.Error(w, "payment link is not active", http.StatusGone)
+			return
+		}
+		c, err := CheckoutFromPaymentLink(&resp.Data)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+		http.Redirect(w, r, c.URL, http.StatusFound)
+	})
+}
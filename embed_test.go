@@ -0,0 +1,101 @@
+package busha_commerce_go
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedGenerator_ButtonHTML(t *testing.T) {
+	c, err := CheckoutFromPaymentLink(&PaymentLink{Name: "iPhone", HostedUrl: "https://pay.example.com/l/1?a=1&b=2"})
+	assert.NoError(t, err)
+
+	g := NewEmbedGenerator(EmbedStyle{Label: `<b>Buy</b>`, Class: "btn", Background: "red;}body{display:none"})
+	got, err := g.ButtonHTML(c)
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), `href="https://pay.example.com/l/1?a=1&amp;b=2"`)
+	assert.Contains(t, string(got), `class="btn"`)
+	assert.Contains(t, string(got), `&lt;b&gt;Buy&lt;/b&gt;`)
+	assert.Contains(t, string(got), `color:#ffffff`)
+	assert.NotContains(t, string(got), `display:none`)
+}
+
+func TestEmbedGenerator_IframeHTML(t *testing.T) {
+	c, err := CheckoutFromCharge(&Charge{BusinessName: `"Astro"`, HostedUrl: "https://pay.example.com/c/1"})
+	assert.NoError(t, err)
+
+	got, err := NewEmbedGenerator(EmbedStyle{Height: "480"}).IframeHTML(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `<iframe src="https://pay.example.com/c/1" title="&#34;Astro&#34;" width="100%" height="480" `+
+		`style="border:0" allow="clipboard-write"></iframe>`, string(got))
+
+	g := NewEmbedGenerator(EmbedStyle{})
+	g.Iframe = template.Must(template.New("custom").Parse(`<a href="{{.URL}}">{{.Name}} {{.Amount}} {{.Currency}}</a>`))
+	got, err = g.IframeHTML(Checkout{URL: "https://pay.example.com/c/1", Name: "Astro", Amount: 5000, Currency: "NGN"})
+	assert.NoError(t, err)
+	assert.Equal(t, `<a href="https://pay.example.com/c/1">Astro 5000 NGN</a>`, string(got))
+}
+
+func TestCheckout_UnsafeURL(t *testing.T) {
+	_, err := CheckoutFromPaymentLink(&PaymentLink{HostedUrl: "javascript:alert(1)"})
+	assert.Error(t, err)
+	_, err = CheckoutFromCharge(&Charge{})
+	assert.Error(t, err)
+	_, err = NewEmbedGenerator(EmbedStyle{}).ButtonHTML(Checkout{URL: "//pay.example.com"})
+	assert.Error(t, err)
+}
+
+func TestPaymentLinkService_RedirectHandler(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/payment_links/11111111-1111-1111-1111-111111111111":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"active":true,"hosted_url":"https://pay.example.com/l/active"}}`))
+		case "/payment_links/22222222-2222-2222-2222-222222222222":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"active":false,"hosted_url":"https://pay.example.com/l/inactive"}}`))
+		case "/payment_links/33333333-3333-3333-3333-333333333333":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"name":"Unauthorized","message":"Invalid API key"}}`))
+		case "/payment_links/44444444-4444-4444-4444-444444444444":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"name":"ServiceUnavailable","message":"Try again later"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":"error","message":"payment link not found"}`))
+		}
+	})
+	h := client.PaymentLink.RedirectHandler()
+
+	tests := []struct {
+		path     string
+		code     int
+		location string
+	}{
+		{path: "/pay/11111111-1111-1111-1111-111111111111", code: http.StatusFound, location: "https://pay.example.com/l/active"},
+		{path: "/pay/22222222-2222-2222-2222-222222222222", code: http.StatusGone},
+		{path: "/pay/55555555-5555-5555-5555-555555555555", code: http.StatusNotFound},
+		{path: "/pay/33333333-3333-3333-3333-333333333333", code: http.StatusBadGateway},
+		{path: "/pay/44444444-4444-4444-4444-444444444444", code: http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.code, rec.Code)
+			assert.Equal(t, tt.location, rec.Header().Get("Location"))
+		})
+	}
+
+	// Segments that are not link IDs never reach the API.
+	calls = 0
+	for _, path := range []string{"/pay/", "/pay/active", "/pay/%3Flimit=100", "/pay/.."} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code, path)
+	}
+	assert.Equal(t, 0, calls)
+}
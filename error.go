@@ -4,6 +4,10 @@ import "strings"
 
 type ErrResponse struct {
 	Errors Error `json:"error"`
+	//Mode is whether the failed call used a live or a test key
+	Mode Mode `json:"-"`
+	//StatusCode is the HTTP status of the failed call
+	StatusCode int `json:"-"`
 }
 
 type Error struct {
@@ -1,6 +1,7 @@
 package busha_commerce_go
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -49,17 +50,25 @@ type ListEventResponse struct {
 }
 
 func (s *EventService) List(params ListParameters) (*ListEventResponse, error) {
+	return s.ListWithContext(context.Background(), params)
+}
+
+func (s *EventService) ListWithContext(ctx context.Context, params ListParameters) (*ListEventResponse, error) {
 	var resp = new(ListEventResponse)
-	err := s.client.call("GET", fmt.Sprintf("/events?sort=%s&limit=%d&page=%d",
+	err := s.client.call(ctx, OpEventList, "GET", fmt.Sprintf("/events?sort=%s&limit=%d&page=%d",
 		params.Sort, params.Limit, params.Page), params, &resp)
 	return resp, err
 }
 
 func (s *EventService) Get(id string) (*EventResponse, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+func (s *EventService) GetWithContext(ctx context.Context, id string) (*EventResponse, error) {
 	var resp = new(EventResponse)
 	if id == "" {
 		return nil, errors.New("no eventID provided")
 	}
-	err := s.client.call("GET", fmt.Sprintf("/events/%s", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpEventGet, "GET", fmt.Sprintf("/events/%s", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
@@ -0,0 +1,199 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"errors"
+	"github.com/gobuffalo/uuid"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TrackedCharge is the state an ExpiryScheduler keeps for one charge.
+type TrackedCharge struct {
+	ID        string    `json:"id"`
+	Reference string    `json:"reference"`
+	ExpiresAt time.Time `json:"expires_at"`
+	//Warned is true once OnExpiringSoon has fired for the charge
+	Warned bool `json:"warned"`
+}
+
+// ExpiryStore persists tracked charges so an ExpiryScheduler survives restarts.
+type ExpiryStore interface {
+	Save(ctx context.Context, c TrackedCharge) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]TrackedCharge, error)
+}
+
+// MemoryExpiryStore is an ExpiryStore that keeps charges in memory.
+type MemoryExpiryStore struct {
+	mu      sync.Mutex
+	charges map[string]TrackedCharge
+}
+
+// NewMemoryExpiryStore returns an empty MemoryExpiryStore.
+func NewMemoryExpiryStore() *MemoryExpiryStore {
+	return &MemoryExpiryStore{charges: make(map[string]TrackedCharge)}
+}
+
+func (m *MemoryExpiryStore) Save(_ context.Context, c TrackedCharge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.charges[c.ID] = c
+	return nil
+}
+
+func (m *MemoryExpiryStore) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.charges, id)
+	return nil
+}
+
+func (m *MemoryExpiryStore) List(context.Context) ([]TrackedCharge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]TrackedCharge, 0, len(m.charges))
+	for _, c := range m.charges {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ExpiresAt.Before(out[j].ExpiresAt) })
+	return out, nil
+}
+
+// ExpiryScheduler tracks open charges and notifies when they are about to
+// expire or have expired. Each charge is re-fetched with ChargeService.Get
+// before a callback fires, so paid charges are dropped silently.
+type ExpiryScheduler struct {
+	//Warning is how long before expiry OnExpiringSoon fires, defaults to 5 minutes
+	Warning time.Duration
+	//OnExpiringSoon is called once per charge when it enters the warning window
+	OnExpiringSoon func(ctx context.Context, c *Charge)
+	//OnExpired is called once the charge has expired without payment
+	OnExpired func(ctx context.Context, c *Charge)
+	//AutoCancel cancels expired charges with ChargeService.Cancel before OnExpired fires
+	AutoCancel bool
+	//OnError is called with errors met during Tick, with an empty id when
+	//the tracked charges could not be listed
+	OnError func(id string, err error)
+
+	charges *ChargeService
+	store   ExpiryStore
+	now     func() time.Time
+}
+
+// NewExpiryScheduler returns a scheduler confirming charges through charges
+// and persisting its state in store. A nil store keeps state in memory.
+func NewExpiryScheduler(charges *ChargeService, store ExpiryStore) *ExpiryScheduler {
+	if store == nil {
+		store = NewMemoryExpiryStore()
+	}
+	return &ExpiryScheduler{
+		Warning: 5 * time.Minute,
+		charges: charges,
+		store:   store,
+		now:     time.Now,
+	}
+}
+
+// Track starts watching c until it is paid or expires.
+func (s *ExpiryScheduler) Track(ctx context.Context, c *Charge) error {
+	if c == nil || c.Id == uuid.Nil {
+		return errors.New("no charge provided")
+	}
+	return s.store.Save(ctx, TrackedCharge{ID: c.Id.String(), Reference: c.Reference, ExpiresAt: c.ExpiresAt})
+}
+
+// Untrack stops watching the charge with id.
+func (s *ExpiryScheduler) Untrack(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Tick processes every tracked charge that is due. It can be called from
+// a cron job or left to Run.
+func (s *ExpiryScheduler) Tick(ctx context.Context) error {
+	tracked, err := s.store.List(ctx)
+	if err != nil {
+		if s.OnError != nil && ctx.Err() == nil {
+			s.OnError("", err)
+		}
+		return err
+	}
+	now := s.now()
+	for _, t := range tracked {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if now.Before(t.ExpiresAt.Add(-s.Warning)) || (t.Warned && now.Before(t.ExpiresAt)) {
+			continue
+		}
+		if err = s.process(ctx, t, now); err != nil && s.OnError != nil {
+			s.OnError(t.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *ExpiryScheduler) process(ctx context.Context, t TrackedCharge, now time.Time) error {
+	resp, err := s.charges.GetWithContext(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	charge := &resp.Data
+
+	switch charge.Status() {
+	case ChargeStatusNew:
+	case ChargeStatusPending:
+		// A payment is being confirmed, keep watching until it settles.
+		return nil
+	case ChargeStatusExpired:
+		// The API expired it already, there is nothing left to cancel.
+		if s.OnExpired != nil {
+			s.OnExpired(ctx, charge)
+		}
+		return s.store.Delete(ctx, t.ID)
+	default:
+		return s.store.Delete(ctx, t.ID)
+	}
+
+	if !charge.ExpiresAt.IsZero() {
+		t.ExpiresAt = charge.ExpiresAt
+	}
+	if now.Before(t.ExpiresAt) {
+		if t.Warned || now.Before(t.ExpiresAt.Add(-s.Warning)) {
+			return s.store.Save(ctx, t)
+		}
+		if s.OnExpiringSoon != nil {
+			s.OnExpiringSoon(ctx, charge)
+		}
+		t.Warned = true
+		return s.store.Save(ctx, t)
+	}
+
+	if s.AutoCancel {
+		cancelled, err := s.charges.CancelWithContext(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+		charge = &cancelled.Data
+	}
+	if s.OnExpired != nil {
+		s.OnExpired(ctx, charge)
+	}
+	return s.store.Delete(ctx, t.ID)
+}
+
+// Run calls Tick every interval until ctx is done. Errors are reported to
+// OnError and do not stop it.
+func (s *ExpiryScheduler) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_ = s.Tick(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiryScheduler(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	status := ChargeStatusNew
+	var cancelled bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			cancelled = true
+			status = ChargeStatusCanceled
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"id":%q,"expires_at":%q,"timeline":[{"status":%q,"created_at":"2026-01-01T11:00:00Z"}]}}`,
+			strings.Split(strings.TrimPrefix(r.URL.Path, "/charges/"), "/")[0], expiresAt.Format(time.RFC3339), status)
+	})
+
+	now := expiresAt.Add(-time.Hour)
+	var soon, expired []string
+	store := NewMemoryExpiryStore()
+	s := NewExpiryScheduler(client.Charge, store)
+	s.now = func() time.Time { return now }
+	s.AutoCancel = true
+	s.OnExpiringSoon = func(ctx context.Context, c *Charge) { soon = append(soon, c.Id.String()) }
+	s.OnExpired = func(ctx context.Context, c *Charge) { expired = append(expired, c.Status()) }
+
+	id := uuid.Must(uuid.NewV4())
+	assert.NoError(t, s.Track(context.Background(), &Charge{Id: id, ExpiresAt: expiresAt}))
+
+	assert.NoError(t, s.Tick(context.Background()))
+	assert.Empty(t, soon)
+
+	now = expiresAt.Add(-time.Minute)
+	assert.NoError(t, s.Tick(context.Background()))
+	assert.NoError(t, s.Tick(context.Background()))
+	assert.Equal(t, []string{id.String()}, soon)
+
+	now = expiresAt.Add(time.Second)
+	assert.NoError(t, s.Tick(context.Background()))
+	assert.True(t, cancelled)
+	assert.Equal(t, []string{ChargeStatusCanceled}, expired)
+
+	tracked, _ := store.List(context.Background())
+	assert.Empty(t, tracked)
+}
+
+func TestExpiryScheduler_Paid(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":{"timeline":[{"status":"COMPLETED","created_at":"2026-01-01T11:00:00Z"}]}}`))
+	})
+	store := NewMemoryExpiryStore()
+	s := NewExpiryScheduler(client.Charge, store)
+	s.OnExpired = func(ctx context.Context, c *Charge) { t.Error("paid charge reported as expired") }
+
+	assert.NoError(t, s.Track(context.Background(), &Charge{Id: uuid.Must(uuid.NewV4()), ExpiresAt: time.Now().Add(-time.Minute)}))
+	assert.NoError(t, s.Tick(context.Background()))
+
+	tracked, _ := store.List(context.Background())
+	assert.Empty(t, tracked)
+}
+
+func TestExpiryScheduler_ExpiredByAPI(t *testing.T) {
+	var cancelled bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			cancelled = true
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"timeline":[
+			{"status":"NEW","created_at":"2026-01-01T11:00:00Z"},
+			{"status":"EXPIRED","created_at":"2026-01-01T12:00:00Z"}
+		]}}`))
+	})
+	store := NewMemoryExpiryStore()
+	s := NewExpiryScheduler(client.Charge, store)
+	s.AutoCancel = true
+	var expired []string
+	s.OnExpired = func(ctx context.Context, c *Charge) { expired = append(expired, c.Status()) }
+
+	assert.NoError(t, s.Track(context.Background(), &Charge{Id: uuid.Must(uuid.NewV4()), ExpiresAt: time.Now().Add(-time.Minute)}))
+	assert.NoError(t, s.Tick(context.Background()))
+	assert.Equal(t, []string{ChargeStatusExpired}, expired)
+	assert.False(t, cancelled)
+
+	tracked, _ := store.List(context.Background())
+	assert.Empty(t, tracked)
+}
+
+type flakyExpiryStore struct {
+	*MemoryExpiryStore
+	failures int
+}
+
+func (f *flakyExpiryStore) List(ctx context.Context) ([]TrackedCharge, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("store unavailable")
+	}
+	return f.MemoryExpiryStore.List(ctx)
+}
+
+func TestExpiryScheduler_RunContinuesAfterErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":{"timeline":[{"status":"NEW","created_at":"2026-01-01T11:00:00Z"}]}}`))
+	})
+	store := &flakyExpiryStore{MemoryExpiryStore: NewMemoryExpiryStore(), failures: 1}
+	s := NewExpiryScheduler(client.Charge, store)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var failed []string
+	s.OnError = func(id string, err error) { failed = append(failed, id) }
+	s.OnExpired = func(ctx context.Context, c *Charge) { cancel() }
+	assert.NoError(t, s.Track(ctx, &Charge{Id: uuid.Must(uuid.NewV4()), ExpiresAt: time.Now().Add(-time.Minute)}))
+
+	err := s.Run(ctx, time.Millisecond)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{""}, failed)
+}
@@ -1,6 +1,6 @@
 module github.com/bushaHQ/busha-commerce-go
 
-go 1.19
+go 1.21
 
 require (
 	github.com/gobuffalo/uuid v2.0.5+incompatible
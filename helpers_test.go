@@ -0,0 +1,22 @@
+package busha_commerce_go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client whose requests are served by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := New("test_key", srv.Client())
+	assert.NoError(t, err)
+	client.baseURL, _ = url.Parse(srv.URL)
+	return client
+}
@@ -0,0 +1,21 @@
+module github.com/bushaHQ/busha-commerce-go/invoicepdf
+
+go 1.21
+
+require (
+	github.com/bushaHQ/busha-commerce-go v0.0.0
+	github.com/go-pdf/fpdf v0.9.0
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gobuffalo/uuid v2.0.5+incompatible // indirect
+	github.com/gofrs/uuid v3.3.0+incompatible // indirect
+	github.com/joho/godotenv v1.5.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/bushaHQ/busha-commerce-go => ../
@@ -0,0 +1,277 @@
+// Package invoicepdf renders Busha Commerce invoices as PDF documents in
+// pure Go, with optional branding, line items, the hosted payment link and
+// a QR code pointing to it.
+//
+//	charge, _ := client.Invoice.CreateCharge(invoiceID)
+//	err := invoicepdf.Render(w, &invoice, invoicepdf.Options{
+//		Branding:   invoicepdf.Branding{BusinessName: "Astro Ltd"},
+//		PaymentURL: charge.Data.HostedUrl,
+//	})
+package invoicepdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	commerce "github.com/bushaHQ/busha-commerce-go"
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+// Branding is the business identity printed in the invoice header.
+type Branding struct {
+	BusinessName string
+	//Address is printed under the business name, one line per entry
+	Address []string
+	Email   string
+	//Logo is a PNG or JPEG image
+	Logo []byte
+	//AccentColor is the RGB color of headings and table headers
+	AccentColor [3]int
+}
+
+// Options configures Render.
+type Options struct {
+	Branding Branding
+	//LineItems are printed as the invoice table. When empty, the items stored
+	//on the invoice are used, or a single row built from its description.
+	//Their total must equal the invoice amount
+	LineItems commerce.LineItems
+	//PaymentURL is the hosted payment page of the invoice's charge,
+	//printed as a link and encoded in a QR code
+	PaymentURL string
+	//Now is the issue date printed when the invoice has no creation date
+	Now time.Time
+}
+
+var defaultAccent = [3]int{31, 41, 55}
+
+// Render writes inv as a PDF to w.
+func Render(w io.Writer, inv *commerce.Invoice, opts Options) error {
+	if inv == nil {
+		return errors.New("no invoice provided")
+	}
+	accent := opts.Branding.AccentColor
+	if accent == [3]int{} {
+		accent = defaultAccent
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle("Invoice "+inv.Reference, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+	pageWidth, _ := pdf.GetPageSize()
+	contentWidth := pageWidth - 40
+
+	renderHeader(pdf, tr, inv, opts, accent, contentWidth)
+	renderCustomer(pdf, tr, inv)
+	if err := renderItems(pdf, tr, inv, opts, accent, contentWidth); err != nil {
+		return err
+	}
+	if err := renderPayment(pdf, tr, opts); err != nil {
+		return err
+	}
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(w)
+}
+
+func renderHeader(pdf *fpdf.Fpdf, tr func(string) string, inv *commerce.Invoice, opts Options, accent [3]int, width float64) {
+	b := opts.Branding
+	top := pdf.GetY()
+	if len(b.Logo) > 0 {
+		info := pdf.RegisterImageOptionsReader("logo", fpdf.ImageOptions{ImageType: imageType(b.Logo)}, bytes.NewReader(b.Logo))
+		if info != nil {
+			pdf.ImageOptions("logo", 20, top, 0, 18, false, fpdf.ImageOptions{}, 0, "")
+			pdf.SetY(top + 20)
+		}
+	}
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.SetTextColor(accent[0], accent[1], accent[2])
+	if b.BusinessName != "" {
+		pdf.CellFormat(width/2, 7, tr(b.BusinessName), "", 2, "L", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(80, 80, 80)
+	for _, line := range b.Address {
+		pdf.CellFormat(width/2, 5, tr(line), "", 2, "L", false, 0, "")
+	}
+	if b.Email != "" {
+		pdf.CellFormat(width/2, 5, tr(b.Email), "", 2, "L", false, 0, "")
+	}
+	bottom := pdf.GetY()
+
+	pdf.SetXY(20+width/2, top)
+	pdf.SetFont("Helvetica", "B", 22)
+	pdf.SetTextColor(accent[0], accent[1], accent[2])
+	pdf.CellFormat(width/2, 10, "INVOICE", "", 2, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(80, 80, 80)
+	issued := inv.CreatedAt
+	if issued.IsZero() {
+		issued = opts.Now
+	}
+	rows := [][2]string{{"Reference", inv.Reference}, {"Status", strings.ToUpper(string(inv.Status))}}
+	if !issued.IsZero() {
+		rows = append(rows, [2]string{"Issued", issued.Format("2 Jan 2006")})
+	}
+	if inv.DueDate != nil {
+		rows = append(rows, [2]string{"Due", inv.DueDate.Format("2 Jan 2006")})
+	}
+	for _, r := range rows {
+		pdf.SetX(20 + width/2)
+		pdf.CellFormat(width/2, 5, tr(r[0]+": "+r[1]), "", 2, "R", false, 0, "")
+	}
+	pdf.SetY(math.Max(bottom, pdf.GetY()) + 10)
+}
+
+func renderCustomer(pdf *fpdf.Fpdf, tr func(string) string, inv *commerce.Invoice) {
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, 6, "Bill to", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	if inv.CustomerName != "" {
+		pdf.CellFormat(0, 5, tr(inv.CustomerName), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 5, tr(inv.CustomerEmail), "", 1, "L", false, 0, "")
+	pdf.Ln(8)
+}
+
+func renderItems(pdf *fpdf.Fpdf, tr func(string) string, inv *commerce.Invoice, opts Options, accent [3]int, width float64) error {
+	items := opts.LineItems
+	if len(items) == 0 {
+		stored, err := inv.LineItems()
+		if err != nil {
+			return err
+		}
+		items = stored
+	}
+	if len(items) == 0 {
+		description := inv.Name
+		if inv.Description != "" {
+			description += " - " + inv.Description
+		}
+		items = commerce.LineItems{{Description: description, Quantity: 1, UnitPrice: inv.LocalAmount}}
+	}
+	if math.Abs(items.Total()-inv.LocalAmount) >= 0.005 {
+		return fmt.Errorf("line items total %s does not match invoice amount %s",
+			formatAmount(items.Total()), formatAmount(inv.LocalAmount))
+	}
+
+	cols := []float64{width * 0.44, width * 0.10, width * 0.16, width * 0.12, width * 0.18}
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(accent[0], accent[1], accent[2])
+	pdf.SetTextColor(255, 255, 255)
+	for i, h := range []string{"Description", "Qty", "Unit price", "Tax", "Amount"} {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(cols[i], 8, h, "", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(0, 0, 0)
+	for _, item := range items {
+		pdf.CellFormat(cols[0], 7, tr(item.Description), "B", 0, "L", false, 0, "")
+		pdf.CellFormat(cols[1], 7, formatQuantity(item.Quantity), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(cols[2], 7, formatAmount(item.UnitPrice), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(cols[3], 7, formatRate(item.TaxRate), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(cols[4], 7, formatAmount(item.Total()), "B", 1, "R", false, 0, "")
+	}
+
+	pdf.Ln(2)
+	label := cols[0] + cols[1] + cols[2] + cols[3]
+	summary := [][2]string{{"Subtotal", formatAmount(items.Subtotal())}}
+	if d := items.Discount(); d > 0 {
+		summary = append(summary, [2]string{"Discount", "-" + formatAmount(d)})
+	}
+	for _, t := range items.TaxBreakdown() {
+		if t.Tax > 0 {
+			summary = append(summary, [2]string{"Tax " + formatRate(t.Rate), formatAmount(t.Tax)})
+		}
+	}
+	for _, row := range summary {
+		pdf.CellFormat(label, 6, row[0], "", 0, "R", false, 0, "")
+		pdf.CellFormat(cols[4], 6, row[1], "", 1, "R", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(label, 8, "Total", "", 0, "R", false, 0, "")
+	pdf.CellFormat(cols[4], 8, tr(inv.LocalCurrency+" "+formatAmount(inv.LocalAmount)), "", 1, "R", false, 0, "")
+	pdf.Ln(8)
+	return nil
+}
+
+func renderPayment(pdf *fpdf.Fpdf, tr func(string) string, opts Options) error {
+	if opts.PaymentURL == "" {
+		return nil
+	}
+	png, err := qrcode.Encode(opts.PaymentURL, qrcode.Medium, 256)
+	if err != nil {
+		return fmt.Errorf("encoding payment QR code: %w", err)
+	}
+
+	top := pdf.GetY()
+	pdf.RegisterImageOptionsReader("payment-qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+	pdf.ImageOptions("payment-qr", 20, top, 35, 35, false, fpdf.ImageOptions{}, 0, opts.PaymentURL)
+
+	pdf.SetXY(60, top+4)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, 6, "Pay with crypto", "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(0, 5, "Scan the code or open the link below to pay this invoice.", "", 2, "L", false, 0, "")
+	pdf.SetTextColor(37, 99, 235)
+	pdf.CellFormat(0, 5, tr(opts.PaymentURL), "", 2, "L", false, 0, opts.PaymentURL)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(top + 40)
+	return nil
+}
+
+func imageType(b []byte) string {
+	if bytes.HasPrefix(b, []byte("\x89PNG")) {
+		return "PNG"
+	}
+	return "JPG"
+}
+
+func formatQuantity(q float64) string {
+	if q == math.Trunc(q) {
+		return fmt.Sprintf("%.0f", q)
+	}
+	return fmt.Sprintf("%.2f", q)
+}
+
+func formatRate(r float64) string {
+	if r == 0 {
+		return "-"
+	}
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", r*100), "0"), ".") + "%"
+}
+
+// formatAmount formats v with two decimals and thousands separators.
+func formatAmount(v float64) string {
+	s := fmt.Sprintf("%.2f", math.Abs(v))
+	whole, frac := s[:len(s)-3], s[len(s)-3:]
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if v < 0 {
+		return "-" + b.String() + frac
+	}
+	return b.String() + frac
+}
@@ -0,0 +1,65 @@
+package invoicepdf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	commerce "github.com/bushaHQ/busha-commerce-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	due := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	inv := &commerce.Invoice{
+		Name:          "Payment for Development Services",
+		Description:   "January retainer",
+		CustomerName:  "Astro",
+		CustomerEmail: "syz@g.com",
+		LocalAmount:   1275000,
+		LocalCurrency: "NGN",
+		Status:        commerce.InvoiceUnpaid,
+		Reference:     "INV-0001",
+		CreatedAt:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:       &due,
+	}
+
+	var buf bytes.Buffer
+	err := Render(&buf, inv, Options{
+		Branding:   Branding{BusinessName: "Astro Ltd", Address: []string{"1 Marina", "Lagos"}, Email: "billing@astro.ng"},
+		LineItems:  commerce.LineItems{{Description: "Development", Quantity: 10, UnitPrice: 100000, TaxRate: 0.075}, {Description: "Hosting", Quantity: 1, UnitPrice: 250000, Discount: 50000}},
+		PaymentURL: "https://pay.busha.co/charges/abc",
+	})
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.Contains(t, buf.String(), "https://pay.busha.co/charges/abc")
+
+	assert.Error(t, Render(&buf, nil, Options{}))
+
+	// Items that do not add up to the invoice amount would contradict it.
+	inv.LocalAmount = 1250000
+	err = Render(&buf, inv, Options{LineItems: commerce.LineItems{{Description: "Development", Quantity: 1, UnitPrice: 1275000}}})
+	assert.EqualError(t, err, "line items total 1,275,000.00 does not match invoice amount 1,250,000.00")
+}
+
+func TestRender_StoredLineItems(t *testing.T) {
+	req := commerce.InvoiceRequest{LocalCurrency: "NGN"}
+	assert.NoError(t, req.SetLineItems(commerce.LineItems{{Description: "Development", Quantity: 2, UnitPrice: 500}}))
+	inv := &commerce.Invoice{Name: "Services", CustomerEmail: "syz@g.com", Description: req.Description, Meta: req.Meta, LocalAmount: req.LocalAmount, LocalCurrency: "NGN"}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, inv, Options{}))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func Test_formatRate(t *testing.T) {
+	assert.Equal(t, "7.5%", formatRate(0.075))
+	assert.Equal(t, "10%", formatRate(0.1))
+	assert.Equal(t, "-", formatRate(0))
+}
+
+func Test_formatAmount(t *testing.T) {
+	assert.Equal(t, "1,250,000.00", formatAmount(1250000))
+	assert.Equal(t, "999.50", formatAmount(999.5))
+	assert.Equal(t, "-1,000.00", formatAmount(-1000))
+}
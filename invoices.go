@@ -1,6 +1,8 @@
 package busha_commerce_go
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/gobuffalo/uuid"
@@ -10,19 +12,43 @@ import (
 
 type InvoiceService service
 
+// InvoiceStatus is the state of an invoice.
+type InvoiceStatus string
+
+const (
+	InvoiceDraft         InvoiceStatus = "draft"
+	InvoiceUnpaid        InvoiceStatus = "unpaid"
+	InvoicePartiallyPaid InvoiceStatus = "partially_paid"
+	InvoicePaid          InvoiceStatus = "paid"
+	InvoiceVoid          InvoiceStatus = "void"
+	InvoiceExpired       InvoiceStatus = "expired"
+	InvoiceCancelled     InvoiceStatus = "cancelled"
+)
+
+// AwaitsPayment reports whether an invoice in this status has been issued
+// and can still be paid.
+func (s InvoiceStatus) AwaitsPayment() bool {
+	switch InvoiceStatus(strings.ToLower(string(s))) {
+	case InvoiceDraft, InvoicePaid, InvoiceVoid, InvoiceExpired, InvoiceCancelled, "voided", "canceled":
+		return false
+	}
+	return true
+}
+
 type Invoice struct {
-	Id            uuid.UUID  `json:"id"`
-	BusinessId    uuid.UUID  `json:"business_id"`
-	Name          string     `json:"name"`
-	Description   string     `json:"description"`
-	CustomerName  string     `json:"customer_name"`
-	CustomerEmail string     `json:"customer_email"`
-	LocalAmount   float64    `json:"local_amount,string"`
-	LocalCurrency string     `json:"local_currency"`
-	Status        string     `json:"status"`
-	Reference     string     `json:"reference"`
-	CreatedAt     time.Time  `json:"created_at"`
-	DueDate       *time.Time `json:"due_date"`
+	Id            uuid.UUID       `json:"id"`
+	BusinessId    uuid.UUID       `json:"business_id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	CustomerName  string          `json:"customer_name"`
+	CustomerEmail string          `json:"customer_email"`
+	LocalAmount   float64         `json:"local_amount,string"`
+	LocalCurrency string          `json:"local_currency"`
+	Status        InvoiceStatus   `json:"status"`
+	Reference     string          `json:"reference"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DueDate       *time.Time      `json:"due_date"`
+	Meta          json.RawMessage `json:"meta"`
 }
 
 type InvoiceResponse struct {
@@ -31,8 +57,15 @@ type InvoiceResponse struct {
 }
 
 func (s *InvoiceService) Create(req *InvoiceRequest) (*InvoiceResponse, error) {
+	return s.CreateWithContext(context.Background(), req)
+}
+
+func (s *InvoiceService) CreateWithContext(ctx context.Context, req *InvoiceRequest) (*InvoiceResponse, error) {
 	var resp = new(InvoiceResponse)
-	err := s.client.call("POST", "/invoices", req, &resp)
+	if err := s.client.validate(req); err != nil {
+		return resp, err
+	}
+	err := s.client.call(ctx, OpInvoiceCreate, "POST", "/invoices", req, &resp)
 	return resp, err
 }
 
@@ -41,36 +74,83 @@ type ListInvoiceResponse struct {
 	Data []*Invoice `json:"data"`
 }
 
-func (s *InvoiceService) List(params ListParameters) (*ListPaymentLinksResponse, error) {
-	var resp = new(ListPaymentLinksResponse)
-	err := s.client.call("GET", fmt.Sprintf("/invoices?sort=%s&limit=%d&page=%d",
+func (s *InvoiceService) List(params ListParameters) (*ListInvoiceResponse, error) {
+	return s.ListWithContext(context.Background(), params)
+}
+
+func (s *InvoiceService) ListWithContext(ctx context.Context, params ListParameters) (*ListInvoiceResponse, error) {
+	var resp = new(ListInvoiceResponse)
+	err := s.client.call(ctx, OpInvoiceList, "GET", fmt.Sprintf("/invoices?sort=%s&limit=%d&page=%d",
 		params.Sort, params.Limit, params.Page), params, &resp)
 	return resp, err
 }
 
 func (s *InvoiceService) Get(id string) (*InvoiceResponse, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+func (s *InvoiceService) GetWithContext(ctx context.Context, id string) (*InvoiceResponse, error) {
 	var resp = new(InvoiceResponse)
 	if id == "" {
 		return nil, errors.New("no invoiceID provided")
 	}
-	err := s.client.call("GET", fmt.Sprintf("/invoices/%s", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpInvoiceGet, "GET", fmt.Sprintf("/invoices/%s", strings.TrimSpace(id)), nil, &resp)
+	return resp, err
+}
+
+// Update changes only the fields set in req with a PATCH request, leaving
+// the rest of the invoice as it is.
+func (s *InvoiceService) Update(id string, req *InvoiceUpdateRequest) (*InvoiceResponse, error) {
+	return s.UpdateWithContext(context.Background(), id, req)
+}
+
+func (s *InvoiceService) UpdateWithContext(ctx context.Context, id string, req *InvoiceUpdateRequest) (*InvoiceResponse, error) {
+	var resp = new(InvoiceResponse)
+	if id == "" {
+		return nil, errors.New("no invoiceID provided")
+	}
+	if err := s.client.validate(req); err != nil {
+		return resp, err
+	}
+	err := s.client.call(ctx, OpInvoiceUpdate, "PATCH", fmt.Sprintf("/invoices/%s", strings.TrimSpace(id)), req, &resp)
+	return resp, err
+}
+
+func (s *InvoiceService) Resend(id string) (*Response, error) {
+	return s.ResendWithContext(context.Background(), id)
+}
+
+func (s *InvoiceService) ResendWithContext(ctx context.Context, id string) (*Response, error) {
+	var resp, req = new(Response), struct{}{}
+	if id == "" {
+		return nil, errors.New("no invoiceID provided")
+	}
+	err := s.client.call(ctx, OpInvoiceResend, "POST", fmt.Sprintf("/invoices/%s/resend", strings.TrimSpace(id)), req, &resp)
 	return resp, err
 }
 
 func (s *InvoiceService) Void(id string) (*Response, error) {
+	return s.VoidWithContext(context.Background(), id)
+}
+
+func (s *InvoiceService) VoidWithContext(ctx context.Context, id string) (*Response, error) {
 	var resp = new(Response)
 	if id == "" {
 		return nil, errors.New("no invoiceID provided")
 	}
-	err := s.client.call("DELETE", fmt.Sprintf("/invoices/%s", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpInvoiceVoid, "DELETE", fmt.Sprintf("/invoices/%s", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
 
 func (s *InvoiceService) CreateCharge(id string) (*ChargeResponse, error) {
+	return s.CreateChargeWithContext(context.Background(), id)
+}
+
+func (s *InvoiceService) CreateChargeWithContext(ctx context.Context, id string) (*ChargeResponse, error) {
 	var resp, req = new(ChargeResponse), struct{}{}
 	if id == "" {
 		return nil, errors.New("no invoiceID provided")
 	}
-	err := s.client.call("POST", fmt.Sprintf("/invoices/%s/charge", strings.TrimSpace(id)), req, &resp)
+	err := s.client.call(ctx, OpInvoiceCreateCharge, "POST", fmt.Sprintf("/invoices/%s/charge", strings.TrimSpace(id)), req, &resp)
 	return resp, err
 }
@@ -0,0 +1,203 @@
+package busha_commerce_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// LineItem is a row of an invoice. Amounts are in the invoice's local currency.
+type LineItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	//TaxRate is a fraction of the discounted subtotal i.e. 0.075 for 7.5% VAT
+	TaxRate float64 `json:"tax_rate,omitempty"`
+	//Discount is an amount taken off the line subtotal before tax
+	Discount float64 `json:"discount,omitempty"`
+}
+
+// Subtotal returns Quantity × UnitPrice.
+func (l LineItem) Subtotal() float64 {
+	return roundAmount(l.Quantity * l.UnitPrice)
+}
+
+// Taxable returns the subtotal after discount.
+func (l LineItem) Taxable() float64 {
+	return roundAmount(l.Subtotal() - l.Discount)
+}
+
+// Tax returns the tax charged on the line.
+func (l LineItem) Tax() float64 {
+	return roundAmount(l.Taxable() * l.TaxRate)
+}
+
+// Total returns the taxable amount plus tax.
+func (l LineItem) Total() float64 {
+	return roundAmount(l.Taxable() + l.Tax())
+}
+
+// LineItems are the rows of an invoice.
+type LineItems []LineItem
+
+// TaxLine totals the lines sharing a tax rate.
+type TaxLine struct {
+	Rate    float64
+	Taxable float64
+	Tax     float64
+}
+
+func (ls LineItems) sum(f func(LineItem) float64) float64 {
+	var total float64
+	for _, l := range ls {
+		total += f(l)
+	}
+	return roundAmount(total)
+}
+
+// Subtotal returns the sum of line subtotals.
+func (ls LineItems) Subtotal() float64 { return ls.sum(LineItem.Subtotal) }
+
+// Discount returns the sum of line discounts.
+func (ls LineItems) Discount() float64 {
+	return ls.sum(func(l LineItem) float64 { return l.Discount })
+}
+
+// Tax returns the sum of line taxes.
+func (ls LineItems) Tax() float64 { return ls.sum(LineItem.Tax) }
+
+// Total returns the amount due for all lines.
+func (ls LineItems) Total() float64 { return ls.sum(LineItem.Total) }
+
+// TaxBreakdown groups tax by rate, ordered by rate.
+func (ls LineItems) TaxBreakdown() []TaxLine {
+	byRate := make(map[float64]*TaxLine)
+	for _, l := range ls {
+		t, ok := byRate[l.TaxRate]
+		if !ok {
+			t = &TaxLine{Rate: l.TaxRate}
+			byRate[l.TaxRate] = t
+		}
+		t.Taxable = roundAmount(t.Taxable + l.Taxable())
+		t.Tax = roundAmount(t.Tax + l.Tax())
+	}
+	out := make([]TaxLine, 0, len(byRate))
+	for _, t := range byRate {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Rate < out[j].Rate })
+	return out
+}
+
+// Validate checks every line.
+func (ls LineItems) Validate() error {
+	v := new(validator)
+	if len(ls) == 0 {
+		v.add("line_items", "must not be empty")
+	}
+	for i, l := range ls {
+		field := fmt.Sprintf("line_items[%d]", i)
+		v.required(field+".description", l.Description)
+		if l.Quantity <= 0 {
+			v.add(field+".quantity", "must be greater than zero")
+		}
+		if l.UnitPrice < 0 {
+			v.add(field+".unit_price", "must not be negative")
+		}
+		if l.TaxRate < 0 || l.TaxRate > 1 {
+			v.add(field+".tax_rate", "must be between 0 and 1")
+		}
+		if l.Discount < 0 || l.Discount > l.Subtotal() {
+			v.add(field+".discount", "must be between 0 and the line subtotal")
+		}
+	}
+	return v.err()
+}
+
+// lineItemsMetaKey is the meta key holding the encoded line items.
+const lineItemsMetaKey = "line_items"
+
+// SetLineItems sets the invoice amount to the total of items, appends a
+// readable summary of them to the description and stores them in the meta
+// under "line_items", where Invoice.LineItems reads them back. A summary
+// written by an earlier call is replaced.
+func (r *InvoiceRequest) SetLineItems(items LineItems) error {
+	if err := items.Validate(); err != nil {
+		return err
+	}
+	meta := make(map[string]json.RawMessage)
+	if len(r.Meta) > 0 && !bytes.Equal(r.Meta, []byte("null")) {
+		if err := json.Unmarshal(r.Meta, &meta); err != nil {
+			return fmt.Errorf("invoice meta must be a JSON object: %w", err)
+		}
+	}
+	description := r.Description
+	if raw, ok := meta[lineItemsMetaKey]; ok {
+		var previous LineItems
+		if err := json.Unmarshal(raw, &previous); err == nil {
+			description = strings.TrimSuffix(description, previous.summary())
+		}
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	meta[lineItemsMetaKey] = encoded
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err = ValidateMeta(raw); err != nil {
+		return err
+	}
+
+	if d := strings.TrimSpace(description); d != "" {
+		description = d + "\n\n"
+	}
+	r.Description = description + items.summary()
+	r.LocalAmount = items.Total()
+	r.Meta = raw
+	return nil
+}
+
+// summary lists the items for the invoice description.
+func (ls LineItems) summary() string {
+	var b strings.Builder
+	for _, l := range ls {
+		fmt.Fprintf(&b, "%s x %g @ %.2f = %.2f\n", l.Description, l.Quantity, l.UnitPrice, l.Total())
+	}
+	if d := ls.Discount(); d > 0 {
+		fmt.Fprintf(&b, "Discount: %.2f\n", d)
+	}
+	if t := ls.Tax(); t > 0 {
+		fmt.Fprintf(&b, "Tax: %.2f\n", t)
+	}
+	fmt.Fprintf(&b, "Total: %.2f", ls.Total())
+	return b.String()
+}
+
+// LineItems recovers the line items stored by InvoiceRequest.SetLineItems.
+// It returns nil when the invoice has none.
+func (i *Invoice) LineItems() (LineItems, error) {
+	meta, err := DecodeMeta[map[string]json.RawMessage](i)
+	if err != nil {
+		return nil, fmt.Errorf("decoding invoice line items: %w", err)
+	}
+	raw, ok := meta[lineItemsMetaKey]
+	if !ok {
+		return nil, nil
+	}
+	var items LineItems
+	if err = json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("decoding invoice line items: %w", err)
+	}
+	return items, nil
+}
+
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,61 @@
+package busha_commerce_go
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testLineItems = LineItems{
+	{Description: "Development", Quantity: 10, UnitPrice: 100, TaxRate: 0.075},
+	{Description: "Hosting", Quantity: 1, UnitPrice: 50, Discount: 10},
+	{Description: "Support", Quantity: 2, UnitPrice: 25.5, TaxRate: 0.075},
+}
+
+func TestLineItems_Totals(t *testing.T) {
+	assert.Equal(t, 1101.0, testLineItems.Subtotal())
+	assert.Equal(t, 10.0, testLineItems.Discount())
+	assert.Equal(t, 78.83, testLineItems.Tax())
+	assert.Equal(t, 1169.83, testLineItems.Total())
+	assert.Equal(t, []TaxLine{
+		{Rate: 0, Taxable: 40, Tax: 0},
+		{Rate: 0.075, Taxable: 1051, Tax: 78.83},
+	}, testLineItems.TaxBreakdown())
+}
+
+func TestLineItems_Validate(t *testing.T) {
+	assert.NoError(t, testLineItems.Validate())
+	assertFieldErrors(t, LineItems{{Description: "x", Quantity: 0, UnitPrice: 10, TaxRate: 2, Discount: 5}}.Validate(),
+		[]string{"line_items[0].quantity", "line_items[0].tax_rate", "line_items[0].discount"})
+}
+
+func TestInvoiceRequest_SetLineItems(t *testing.T) {
+	req, err := NewInvoice("Services").
+		Customer("Astro", "syz@g.com").
+		Currency("NGN").
+		Description("January retainer").
+		LineItems(testLineItems...).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, 1169.83, req.LocalAmount)
+	assert.Contains(t, req.Description, "January retainer\n\nDevelopment x 10 @ 100.00 = 1075.00\n")
+
+	assert.NotContains(t, req.Description, "line_items")
+
+	// Setting items again replaces the previous summary and keeps other meta.
+	req.Meta = json.RawMessage(strings.Replace(string(req.Meta), "{", `{"order":"A-1",`, 1))
+	assert.NoError(t, req.SetLineItems(testLineItems[:1]))
+	assert.Equal(t, "January retainer\n\nDevelopment x 10 @ 100.00 = 1075.00\nTax: 75.00\nTotal: 1075.00", req.Description)
+	assert.Contains(t, string(req.Meta), `"order":"A-1"`)
+
+	inv := Invoice{Description: req.Description, Meta: req.Meta}
+	got, err := inv.LineItems()
+	assert.NoError(t, err)
+	assert.Equal(t, testLineItems[:1], got)
+
+	got, err = (&Invoice{Description: "plain"}).LineItems()
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,147 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+var emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*(@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// sensitiveHeaders are never written to logs.
+var sensitiveHeaders = []string{"X-BC-API-KEY", "Authorization", "Cookie"}
+
+// SetLogger sets the structured logger used to log API calls. Calls are
+// logged at debug level, failures at error level. When no logger is set,
+// calls are only logged through Log while LogDebug is enabled.
+func (c *Client) SetLogger(l *slog.Logger) {
+	c.logger = l
+}
+
+// RedactMetaFields masks the values of the given keys wherever they appear
+// in logged request and response bodies, i.e. "phone" or "address".
+func (c *Client) RedactMetaFields(fields ...string) {
+	if c.redactFields == nil {
+		c.redactFields = make(map[string]bool)
+	}
+	for _, f := range fields {
+		c.redactFields[strings.ToLower(f)] = true
+	}
+}
+
+func (c *Client) slogger() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	if !c.LogDebug || c.Log == nil {
+		return nil
+	}
+	return slog.New(slog.NewTextHandler(printfWriter{c.Log}, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// printfWriter adapts the legacy Logger to an io.Writer.
+type printfWriter struct {
+	log Logger
+}
+
+func (w printfWriter) Write(p []byte) (int, error) {
+	w.log.Printf("%s", p)
+	return len(p), nil
+}
+
+func (c *Client) logging(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Result, error) {
+		logger := c.slogger()
+		if logger == nil {
+			return next(ctx, req)
+		}
+		logger = logger.With(
+			slog.String("operation", req.Operation),
+			slog.String("method", req.Method),
+			slog.String("path", req.Path),
+			slog.String("mode", string(req.Mode)),
+		)
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			logger.DebugContext(ctx, "busha request",
+				slog.Any("headers", redactHeader(req.Header)),
+				slog.Any("body", c.redactBody(req.Body)))
+		}
+
+		start := time.Now()
+		res, err := next(ctx, req)
+		attrs := []any{slog.Duration("latency", time.Since(start))}
+		if res != nil {
+			attrs = append(attrs, slog.Int("status", res.StatusCode), slog.Int("retries", res.Retries))
+		}
+
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			if e, ok := err.(ErrResponse); ok {
+				attrs = append(attrs, slog.String("error_name", e.Errors.Name))
+			}
+			logger.ErrorContext(ctx, "busha request failed", attrs...)
+			return res, err
+		}
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, slog.Any("response", c.redactBody(req.Response)))
+			logger.DebugContext(ctx, "busha response", attrs...)
+		}
+		return res, err
+	}
+}
+
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, redacted)
+		}
+	}
+	return out
+}
+
+// redactBody returns a copy of v, as decoded JSON, with emails masked and
+// configured fields replaced.
+func (c *Client) redactBody(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	var out interface{}
+	if err = json.Unmarshal(b, &out); err != nil {
+		return redacted
+	}
+	return c.redactValue(out)
+}
+
+func (c *Client) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if c.redactFields[strings.ToLower(k)] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = c.redactValue(val)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = c.redactValue(v[i])
+		}
+		return v
+	case string:
+		return emailPattern.ReplaceAllString(v, "$1***$2")
+	default:
+		return v
+	}
+}
@@ -0,0 +1,45 @@
+package busha_commerce_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SetLogger(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":{"reference":"ref","meta":{"email":"sarah.shaw@example.co","phone":"0987654321"}}}`))
+	})
+	var buf bytes.Buffer
+	client.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	client.RedactMetaFields("phone")
+
+	_, err := client.Charge.Create(&ChargeRequest{
+		Meta: json.RawMessage(`{"name":"test","email":"sarah.shaw@example.co","phone":"0987654321"}`),
+	})
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `"operation":"charge.create"`)
+	assert.Contains(t, out, `"msg":"busha response"`)
+	assert.Contains(t, out, `"status":200`)
+	assert.Contains(t, out, "s***@example.co")
+	assert.NotContains(t, out, "sarah.shaw@example.co")
+	assert.NotContains(t, out, "0987654321")
+	assert.NotContains(t, out, "test_key")
+}
+
+func Test_redactHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-BC-API-KEY", "live_secret")
+	h.Set("X-Trace", "abc")
+
+	got := redactHeader(h)
+	assert.Equal(t, redacted, got.Get("X-BC-API-KEY"))
+	assert.Equal(t, "abc", got.Get("X-Trace"))
+	assert.Equal(t, "live_secret", h.Get("X-BC-API-KEY"))
+}
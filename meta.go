@@ -0,0 +1,97 @@
+package busha_commerce_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+const (
+	//MaxMetaSize is the largest encoded meta accepted on a request, in bytes
+	MaxMetaSize = 8 * 1024
+	//MaxMetaKeyLength is the longest meta key accepted on a request
+	MaxMetaKeyLength = 64
+)
+
+var metaKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// MetaCarrier is implemented by every type holding meta: Charge, EventData,
+// ChargeRequest, Invoice and InvoiceRequest.
+type MetaCarrier interface {
+	RawMeta() json.RawMessage
+}
+
+func (c Charge) RawMeta() json.RawMessage {
+	return c.Meta
+}
+
+func (d EventData) RawMeta() json.RawMessage {
+	return d.Meta
+}
+
+func (r ChargeRequest) RawMeta() json.RawMessage {
+	return r.Meta
+}
+
+func (i Invoice) RawMeta() json.RawMessage {
+	return i.Meta
+}
+
+func (r InvoiceRequest) RawMeta() json.RawMessage {
+	return r.Meta
+}
+
+// DecodeMeta decodes the meta of m into a T. Missing meta decodes to the zero T.
+//
+//	order, err := DecodeMeta[Order](charge)
+func DecodeMeta[T any](m MetaCarrier) (T, error) {
+	var v T
+	raw := m.RawMeta()
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return v, nil
+	}
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+// SetMeta marshals v into the request meta after checking its size and keys.
+func (r *ChargeRequest) SetMeta(v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err = ValidateMeta(raw); err != nil {
+		return err
+	}
+	r.Meta = raw
+	return nil
+}
+
+// ValidateMeta checks that raw is a JSON object no larger than MaxMetaSize
+// whose keys are at most MaxMetaKeyLength letters, digits, '_', '.' or '-'.
+func ValidateMeta(raw json.RawMessage) error {
+	v := new(validator)
+	v.meta("meta", raw)
+	return v.err()
+}
+
+func (v *validator) meta(field string, raw json.RawMessage) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return
+	}
+	if len(raw) > MaxMetaSize {
+		v.add(field, "must not be larger than %d bytes", MaxMetaSize)
+		return
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		v.add(field, "must be a JSON object")
+		return
+	}
+	for k := range obj {
+		if len(k) > MaxMetaKeyLength || !metaKeyPattern.MatchString(k) {
+			v.add(fmt.Sprintf("%s.%s", field, k), "is not a valid meta key")
+		}
+	}
+}
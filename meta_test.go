@@ -0,0 +1,47 @@
+package busha_commerce_go
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testOrder struct {
+	OrderID string `json:"order_id"`
+	Items   int    `json:"items"`
+}
+
+func TestDecodeMeta(t *testing.T) {
+	raw := json.RawMessage(`{"order_id":"A-1","items":2}`)
+	want := testOrder{OrderID: "A-1", Items: 2}
+
+	for _, m := range []MetaCarrier{Charge{Meta: raw}, &Charge{Meta: raw}, EventData{Meta: raw}, ChargeRequest{Meta: raw}} {
+		got, err := DecodeMeta[testOrder](m)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	got, err := DecodeMeta[testOrder](Charge{Meta: json.RawMessage("null")})
+	assert.NoError(t, err)
+	assert.Equal(t, testOrder{}, got)
+
+	var charge Charge
+	assert.NoError(t, json.Unmarshal([]byte(`{"meta":{"order_id":"A-2","items":1}}`), &charge))
+	got, err = DecodeMeta[testOrder](charge)
+	assert.NoError(t, err)
+	assert.Equal(t, "A-2", got.OrderID)
+}
+
+func TestChargeRequest_SetMeta(t *testing.T) {
+	var req ChargeRequest
+	assert.NoError(t, req.SetMeta(testOrder{OrderID: "A-1", Items: 2}))
+	got, err := DecodeMeta[testOrder](req)
+	assert.NoError(t, err)
+	assert.Equal(t, "A-1", got.OrderID)
+
+	assertFieldErrors(t, req.SetMeta([]string{"a"}), []string{"meta"})
+	assertFieldErrors(t, req.SetMeta(map[string]string{"bad key": "v"}), []string{"meta.bad key"})
+	assertFieldErrors(t, req.SetMeta(map[string]string{"blob": strings.Repeat("a", MaxMetaSize)}), []string{"meta"})
+}
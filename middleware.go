@@ -0,0 +1,114 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Operation names identify the SDK method that issued a request.
+const (
+	OpAddressCreate = "address.create"
+	OpAddressList   = "address.list"
+	OpAddressGet    = "address.get"
+
+	OpChargeCreate  = "charge.create"
+	OpChargeList    = "charge.list"
+	OpChargeGet     = "charge.get"
+	OpChargeResolve = "charge.resolve"
+	OpChargeCancel  = "charge.cancel"
+
+	OpEventList = "event.list"
+	OpEventGet  = "event.get"
+
+	OpInvoiceCreate       = "invoice.create"
+	OpInvoiceList         = "invoice.list"
+	OpInvoiceGet          = "invoice.get"
+	OpInvoiceUpdate       = "invoice.update"
+	OpInvoiceResend       = "invoice.resend"
+	OpInvoiceVoid         = "invoice.void"
+	OpInvoiceCreateCharge = "invoice.create_charge"
+
+	OpPaymentLinkCreate       = "payment_link.create"
+	OpPaymentLinkList         = "payment_link.list"
+	OpPaymentLinkGet          = "payment_link.get"
+	OpPaymentLinkUpdate       = "payment_link.update"
+	OpPaymentLinkPatch        = "payment_link.patch"
+	OpPaymentLinkToggleStatus = "payment_link.toggle_status"
+	OpPaymentLinkDelete       = "payment_link.delete"
+	OpPaymentLinkCreateCharge = "payment_link.create_charge"
+)
+
+// Request is the SDK-level description of an API call as seen by middleware.
+type Request struct {
+	//Operation is the name of the SDK operation i.e. charge.create
+	Operation string
+	//Method is the HTTP method of the call
+	Method string
+	//Path is the API path including the query string
+	Path string
+	//ResourceID is the ID of the resource the call acts on, if any
+	ResourceID string
+	//Body is the request payload before it is encoded
+	Body interface{}
+	//Header holds extra headers sent with the request
+	Header http.Header
+	//Response is the value the response body is decoded into
+	Response interface{}
+	//Mode is whether the call uses a live or a test key
+	Mode Mode
+
+	secretKey string
+}
+
+// Result describes the outcome of a call as seen by middleware.
+type Result struct {
+	//StatusCode is the HTTP status code of the final attempt
+	StatusCode int
+	//Header is the header of the final response
+	Header http.Header
+	//Retries is the number of times the call was retried
+	Retries int
+}
+
+// Handler performs a call. The error returned for an API failure is an ErrResponse.
+type Handler func(ctx context.Context, req *Request) (*Result, error)
+
+// Middleware wraps a Handler to add behaviour around every call.
+type Middleware func(next Handler) Handler
+
+// Use appends middleware to the chain. The first middleware added is the outermost.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+func (c *Client) handler() Handler {
+	h := c.logging(c.send)
+	if c.breaker != nil {
+		h = c.breaker.Middleware(h)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// resourceID extracts the resource ID from paths such as /charges/{id}/resolve.
+func resourceID(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx whose calls send key in the
+// Idempotency-Key header.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
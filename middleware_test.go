@@ -0,0 +1,63 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Use(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "trace-id", r.Header.Get("X-Trace"))
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"name":"NotFound","message":"Charge not found"}}`))
+	})
+
+	var order []string
+	var seen *Request
+	var seenErr error
+	var seenStatus int
+	client.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			order = append(order, "outer")
+			req.Header.Set("X-Trace", "trace-id")
+			res, err := next(ctx, req)
+			seen, seenErr, seenStatus = req, err, res.StatusCode
+			return res, err
+		}
+	}, func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			order = append(order, "inner")
+			return next(ctx, req)
+		}
+	})
+
+	_, err := client.Charge.Resolve("charge-id", "manual")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, OpChargeResolve, seen.Operation)
+	assert.Equal(t, "charge-id", seen.ResourceID)
+	assert.Equal(t, http.StatusNotFound, seenStatus)
+	if assert.IsType(t, ErrResponse{}, seenErr) {
+		assert.Equal(t, "NotFound", seenErr.(ErrResponse).Errors.Name)
+	}
+}
+
+func Test_resourceID(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/charges", want: ""},
+		{path: "/charges?sort=asc&limit=1&page=1", want: ""},
+		{path: "/charges/abc", want: "abc"},
+		{path: "/charges/abc/resolve", want: "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, resourceID(tt.path))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Mode tells whether a secret key acts on live or sandbox data.
+type Mode string
+
+const (
+	LiveMode Mode = "live"
+	TestMode Mode = "test"
+)
+
+// Environment is the deployment environment a Client runs in.
+type Environment string
+
+const (
+	Production  Environment = "production"
+	Staging     Environment = "staging"
+	Development Environment = "development"
+)
+
+// ErrModeMismatch is returned when a key's mode is not allowed in the
+// Client's environment, i.e. a live key outside production.
+type ErrModeMismatch struct {
+	Mode        Mode
+	Environment Environment
+}
+
+func (e ErrModeMismatch) Error() string {
+	return fmt.Sprintf("%s key cannot be used in %s environment", e.Mode, e.Environment)
+}
+
+func modeOf(key string) Mode {
+	if strings.HasPrefix(key, liveKeyPrefix) {
+		return LiveMode
+	}
+	return TestMode
+}
+
+// allowedMode returns the only key mode permitted in env.
+func (env Environment) allowedMode() Mode {
+	if env == Production {
+		return LiveMode
+	}
+	return TestMode
+}
+
+// EnvironmentProvider is implemented by CredentialProviders that know the
+// environment their keys are used in. Clients created from them refuse keys
+// whose mode is not allowed there.
+type EnvironmentProvider interface {
+	Environment() Environment
+}
+
+// ForEnvironment ties p to env, i.e. so that a rotated live key is refused
+// outside production.
+func ForEnvironment(env Environment, p CredentialProvider) CredentialProvider {
+	return environmentCredentials{CredentialProvider: p, env: env}
+}
+
+type environmentCredentials struct {
+	CredentialProvider
+	env Environment
+}
+
+func (e environmentCredentials) Environment() Environment {
+	return e.env
+}
+
+// checkMode returns ErrModeMismatch when key is not allowed in the Client's environment.
+func (c *Client) checkMode(key string) error {
+	if m := modeOf(key); c.environment != "" && m != c.environment.allowedMode() {
+		return ErrModeMismatch{Mode: m, Environment: c.environment}
+	}
+	return nil
+}
+
+// NewForEnvironment is like New but refuses live keys outside production
+// and test keys in production.
+func NewForEnvironment(env Environment, key string, httpClient *http.Client) (*Client, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	return NewWithCredentials(ForEnvironment(env, StaticCredentials(key)), httpClient)
+}
+
+// Mode returns whether the Client's current key is a live or a test key.
+// It returns an empty Mode when the key cannot be fetched.
+func (c *Client) Mode() Mode {
+	key, err := c.secretKey(context.Background())
+	if err != nil {
+		return ""
+	}
+	return modeOf(key)
+}
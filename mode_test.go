@@ -0,0 +1,46 @@
+package busha_commerce_go
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewForEnvironment(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     Environment
+		key     string
+		want    Mode
+		wantErr error
+	}{
+		{name: "live key in production", env: Production, key: "live_key", want: LiveMode},
+		{name: "test key in development", env: Development, key: "test_key", want: TestMode},
+		{name: "live key in staging", env: Staging, key: "live_key", wantErr: ErrModeMismatch{Mode: LiveMode, Environment: Staging}},
+		{name: "test key in production", env: Production, key: "test_key", wantErr: ErrModeMismatch{Mode: TestMode, Environment: Production}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewForEnvironment(tt.env, tt.key, nil)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Mode())
+		})
+	}
+}
+
+func TestErrResponse_Mode(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"name":"NotFound","message":"Charge not found"}}`))
+	})
+
+	_, err := client.Charge.Get("id")
+	if assert.IsType(t, ErrResponse{}, err) {
+		assert.Equal(t, TestMode, err.(ErrResponse).Mode)
+	}
+}
@@ -0,0 +1,166 @@
+// Package otel instruments a Busha Commerce Client with OpenTelemetry
+// tracing and metrics.
+//
+//	client, _ := commerce.New(secretKey, nil)
+//	if err := otel.Instrument(client); err != nil {
+//		log.Fatal(err)
+//	}
+//	charge, err := client.Charge.CreateWithContext(ctx, req)
+package otel
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	commerce "github.com/bushaHQ/busha-commerce-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/bushaHQ/busha-commerce-go/otel"
+
+// Attribute keys recorded on spans and metrics.
+const (
+	OperationKey  = attribute.Key("busha.operation")
+	ModeKey       = attribute.Key("busha.mode")
+	ResourceIDKey = attribute.Key("busha.resource_id")
+	RetriesKey    = attribute.Key("busha.retries")
+	ErrorNameKey  = attribute.Key("busha.error.name")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider used to create spans.
+// The global provider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record metrics.
+// The global provider is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators used to inject the trace context
+// into outgoing request headers. The global propagators are used by default.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Instrument adds the tracing and metrics middleware to client.
+func Instrument(client *commerce.Client, opts ...Option) error {
+	mw, err := Middleware(opts...)
+	if err != nil {
+		return err
+	}
+	client.Use(mw)
+	return nil
+}
+
+// Middleware returns a commerce.Middleware that creates a span per SDK
+// operation and records request latency and error counts.
+func Middleware(opts ...Option) (commerce.Middleware, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram("busha.commerce.request.duration",
+		metric.WithDescription("Duration of Busha Commerce API calls."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	requests, err := meter.Int64Counter("busha.commerce.requests",
+		metric.WithDescription("Number of Busha Commerce API calls."))
+	if err != nil {
+		return nil, err
+	}
+	failures, err := meter.Int64Counter("busha.commerce.errors",
+		metric.WithDescription("Number of failed Busha Commerce API calls."))
+	if err != nil {
+		return nil, err
+	}
+	retries, err := meter.Int64Counter("busha.commerce.retries",
+		metric.WithDescription("Number of retried Busha Commerce API calls."))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next commerce.Handler) commerce.Handler {
+		return func(ctx context.Context, req *commerce.Request) (*commerce.Result, error) {
+			attrs := []attribute.KeyValue{
+				OperationKey.String(req.Operation),
+				MethodKey.String(req.Method),
+				ModeKey.String(string(req.Mode)),
+			}
+			spanAttrs := attrs
+			if req.ResourceID != "" {
+				spanAttrs = append(spanAttrs, ResourceIDKey.String(req.ResourceID))
+			}
+
+			ctx, span := tracer.Start(ctx, "busha "+req.Operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(spanAttrs...))
+			defer span.End()
+
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			res, err := next(ctx, req)
+			elapsed := time.Since(start).Seconds()
+
+			if res != nil {
+				if res.StatusCode != 0 {
+					attrs = append(attrs, StatusCodeKey.Int(res.StatusCode))
+				}
+				span.SetAttributes(RetriesKey.Int(res.Retries))
+				if res.Retries > 0 {
+					retries.Add(ctx, int64(res.Retries), metric.WithAttributes(attrs...))
+				}
+			}
+			if err != nil {
+				var e commerce.ErrResponse
+				if errors.As(err, &e) {
+					attrs = append(attrs, ErrorNameKey.String(e.Errors.Name))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				failures.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+			span.SetAttributes(attrs...)
+
+			requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+			duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+			return res, err
+		}
+	}, nil
+}
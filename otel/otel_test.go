@@ -0,0 +1,73 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	commerce "github.com/bushaHQ/busha-commerce-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme, r.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestInstrument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"name":"NotFound","message":"Charge not found"}}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	client, err := commerce.New("test_key", &http.Client{Transport: rewriteTransport{target}})
+	assert.NoError(t, err)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	err = Instrument(client,
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+	assert.NoError(t, err)
+
+	_, err = client.Charge.GetWithContext(context.Background(), "charge-id")
+	assert.Error(t, err)
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 1) {
+		span := ended[0]
+		assert.Equal(t, "busha charge.get", span.Name())
+		assert.Equal(t, codes.Error, span.Status().Code)
+		attrs := map[string]string{}
+		for _, kv := range span.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+		}
+		assert.Equal(t, "charge-id", attrs[string(ResourceIDKey)])
+		assert.Equal(t, "404", attrs[string(StatusCodeKey)])
+		assert.Equal(t, "NotFound", attrs[string(ErrorNameKey)])
+	}
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	names := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			names[m.Name] = true
+		}
+	}
+	assert.True(t, names["busha.commerce.request.duration"])
+	assert.True(t, names["busha.commerce.errors"])
+}
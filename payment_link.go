@@ -1,6 +1,7 @@
 package busha_commerce_go
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/gobuffalo/uuid"
@@ -15,19 +16,39 @@ const (
 	FixedPrice PaymentLinkType = "fixed_price"
 )
 
+// RequestedInfoField is a piece of information a payment link asks the
+// customer for.
+type RequestedInfoField string
+
+const (
+	RequestName        RequestedInfoField = "name"
+	RequestEmail       RequestedInfoField = "email"
+	RequestPhoneNumber RequestedInfoField = "phone_number"
+)
+
+// Valid reports whether f is a field the API knows.
+func (f RequestedInfoField) Valid() bool {
+	switch f {
+	case RequestName, RequestEmail, RequestPhoneNumber:
+		return true
+	}
+	return false
+}
+
 type PaymentLinkService service
 
 type PaymentLink struct {
-	Id              uuid.UUID       `json:"id"`
-	BusinessId      string          `json:"business_id"`
-	Name            string          `json:"name"`
-	Description     string          `json:"description"`
-	PaymentLinkType PaymentLinkType `json:"payment_link_type"`
-	RequestedInfo   []string        `json:"requested_info"`
-	LocalAmount     float64         `json:"local_amount,string"`
-	LocalCurrency   string          `json:"local_currency"`
-	Active          bool            `json:"active"`
-	CreatedAt       time.Time       `json:"created_at"`
+	Id              uuid.UUID            `json:"id"`
+	BusinessId      string               `json:"business_id"`
+	Name            string               `json:"name"`
+	Description     string               `json:"description"`
+	PaymentLinkType PaymentLinkType      `json:"payment_link_type"`
+	RequestedInfo   []RequestedInfoField `json:"requested_info"`
+	LocalAmount     float64              `json:"local_amount,string"`
+	LocalCurrency   string               `json:"local_currency"`
+	HostedUrl       string               `json:"hosted_url"`
+	Active          bool                 `json:"active"`
+	CreatedAt       time.Time            `json:"created_at"`
 }
 
 type PaymentLinkResponse struct {
@@ -36,8 +57,15 @@ type PaymentLinkResponse struct {
 }
 
 func (s *PaymentLinkService) Create(req *PaymentLinkRequest) (*PaymentLinkResponse, error) {
+	return s.CreateWithContext(context.Background(), req)
+}
+
+func (s *PaymentLinkService) CreateWithContext(ctx context.Context, req *PaymentLinkRequest) (*PaymentLinkResponse, error) {
 	var resp = new(PaymentLinkResponse)
-	err := s.client.call("POST", "/payment_links", req, &resp)
+	if err := s.client.validate(req); err != nil {
+		return resp, err
+	}
+	err := s.client.call(ctx, OpPaymentLinkCreate, "POST", "/payment_links", req, &resp)
 	return resp, err
 }
 
@@ -47,53 +75,104 @@ type ListPaymentLinksResponse struct {
 }
 
 func (s *PaymentLinkService) List(params ListParameters) (*ListPaymentLinksResponse, error) {
+	return s.ListWithContext(context.Background(), params)
+}
+
+func (s *PaymentLinkService) ListWithContext(ctx context.Context, params ListParameters) (*ListPaymentLinksResponse, error) {
 	var resp = new(ListPaymentLinksResponse)
-	err := s.client.call("GET", fmt.Sprintf("/payment_links?sort=%s&limit=%d&page=%d",
+	err := s.client.call(ctx, OpPaymentLinkList, "GET", fmt.Sprintf("/payment_links?sort=%s&limit=%d&page=%d",
 		params.Sort, params.Limit, params.Page), params, &resp)
 	return resp, err
 }
 
 func (s *PaymentLinkService) Get(id string) (*PaymentLinkResponse, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+func (s *PaymentLinkService) GetWithContext(ctx context.Context, id string) (*PaymentLinkResponse, error) {
 	var resp = new(PaymentLinkResponse)
 	if id == "" {
 		return nil, errors.New("no payment link ID provided")
 	}
-	err := s.client.call("GET", fmt.Sprintf("/payment_links/%s", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpPaymentLinkGet, "GET", fmt.Sprintf("/payment_links/%s", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
 
 func (s *PaymentLinkService) Update(id string, req *PaymentLinkRequest) (*Response, error) {
+	return s.UpdateWithContext(context.Background(), id, req)
+}
+
+func (s *PaymentLinkService) UpdateWithContext(ctx context.Context, id string, req *PaymentLinkRequest) (*Response, error) {
 	var resp = new(Response)
 	if id == "" {
 		return nil, errors.New("no payment link ID provided")
 	}
-	err := s.client.call("PUT", fmt.Sprintf("/payment_links/%s", strings.TrimSpace(id)), req, &resp)
+	if err := s.client.validate(req); err != nil {
+		return resp, err
+	}
+	err := s.client.call(ctx, OpPaymentLinkUpdate, "PUT", fmt.Sprintf("/payment_links/%s", strings.TrimSpace(id)), req, &resp)
+	return resp, err
+}
+
+// Patch changes only the fields set in req with a PATCH request, leaving
+// the rest of the payment link as it is. Use Update to replace it whole.
+func (s *PaymentLinkService) Patch(id string, req *PaymentLinkUpdateRequest) (*PaymentLinkResponse, error) {
+	return s.PatchWithContext(context.Background(), id, req)
+}
+
+func (s *PaymentLinkService) PatchWithContext(ctx context.Context, id string, req *PaymentLinkUpdateRequest) (*PaymentLinkResponse, error) {
+	var resp = new(PaymentLinkResponse)
+	if id == "" {
+		return nil, errors.New("no payment link ID provided")
+	}
+	if err := s.client.validate(req); err != nil {
+		return resp, err
+	}
+	err := s.client.call(ctx, OpPaymentLinkPatch, "PATCH", fmt.Sprintf("/payment_links/%s", strings.TrimSpace(id)), req, &resp)
 	return resp, err
 }
 
 func (s *PaymentLinkService) ToggleStatus(id string) (*PaymentLinkResponse, error) {
+	return s.ToggleStatusWithContext(context.Background(), id)
+}
+
+func (s *PaymentLinkService) ToggleStatusWithContext(ctx context.Context, id string) (*PaymentLinkResponse, error) {
 	var resp = new(PaymentLinkResponse)
 	if id == "" {
 		return nil, errors.New("no payment link ID provided")
 	}
-	err := s.client.call("PATCH", fmt.Sprintf("/payment_links/%s/active", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpPaymentLinkToggleStatus, "PATCH", fmt.Sprintf("/payment_links/%s/active", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
 
 func (s *PaymentLinkService) Delete(id string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), id)
+}
+
+func (s *PaymentLinkService) DeleteWithContext(ctx context.Context, id string) (*Response, error) {
 	var resp = new(Response)
 	if id == "" {
 		return nil, errors.New("no payment link ID provided")
 	}
-	err := s.client.call("DELETE", fmt.Sprintf("/payment_links/%s", strings.TrimSpace(id)), nil, &resp)
+	err := s.client.call(ctx, OpPaymentLinkDelete, "DELETE", fmt.Sprintf("/payment_links/%s", strings.TrimSpace(id)), nil, &resp)
 	return resp, err
 }
 
 func (s *PaymentLinkService) CreateCharge(id string, req *ChargeRequest) (*ChargeResponse, error) {
+	return s.CreateChargeWithContext(context.Background(), id, req)
+}
+
+func (s *PaymentLinkService) CreateChargeWithContext(ctx context.Context, id string, req *ChargeRequest) (*ChargeResponse, error) {
 	var resp = new(ChargeResponse)
 	if id == "" {
 		return nil, errors.New("no payment link ID provided")
 	}
-	err := s.client.call("POST", fmt.Sprintf("/payment_links/%s/charge", strings.TrimSpace(id)), req, &resp)
+	// A nil request is sent as is, letting the link's own price apply.
+	if req != nil {
+		if err := s.client.validate(req); err != nil {
+			return resp, err
+		}
+	}
+	err := s.client.call(ctx, OpPaymentLinkCreateCharge, "POST", fmt.Sprintf("/payment_links/%s/charge", strings.TrimSpace(id)), req, &resp)
 	return resp, err
 }
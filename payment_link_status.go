@@ -0,0 +1,107 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// maxToggleAttempts bounds how often setActive toggles a link that another
+// caller keeps flipping back.
+const maxToggleAttempts = 3
+
+// Activate makes the payment link active, toggling it only when it is not.
+func (s *PaymentLinkService) Activate(id string) (*PaymentLinkResponse, error) {
+	return s.ActivateWithContext(context.Background(), id)
+}
+
+func (s *PaymentLinkService) ActivateWithContext(ctx context.Context, id string) (*PaymentLinkResponse, error) {
+	resp, _, err := s.setActive(ctx, id, true)
+	return resp, err
+}
+
+// Deactivate makes the payment link inactive, toggling it only when it is active.
+func (s *PaymentLinkService) Deactivate(id string) (*PaymentLinkResponse, error) {
+	return s.DeactivateWithContext(context.Background(), id)
+}
+
+func (s *PaymentLinkService) DeactivateWithContext(ctx context.Context, id string) (*PaymentLinkResponse, error) {
+	resp, _, err := s.setActive(ctx, id, false)
+	return resp, err
+}
+
+// setActive reads the link and toggles it only while it differs from active.
+// When a toggle does not report the wanted state, another caller may have
+// toggled the link too, so it is read again before toggling once more.
+func (s *PaymentLinkService) setActive(ctx context.Context, id string, active bool) (*PaymentLinkResponse, bool, error) {
+	if id == "" {
+		return nil, false, errors.New("no payment link ID provided")
+	}
+	changed := false
+	for attempt := 0; ; attempt++ {
+		resp, err := s.GetWithContext(ctx, id)
+		if err != nil || resp.Data.Active == active {
+			return resp, changed, err
+		}
+		if attempt == maxToggleAttempts {
+			return resp, changed, fmt.Errorf("payment link %s: still active=%t after %d toggles", id, resp.Data.Active, attempt)
+		}
+		if resp, err = s.ToggleStatusWithContext(ctx, id); err != nil {
+			return resp, changed, err
+		}
+		changed = true
+		if resp.Data.Active == active {
+			return resp, changed, nil
+		}
+	}
+}
+
+// PaymentLinkStatusResult is the outcome of changing one link in
+// SetActiveBulk.
+type PaymentLinkStatusResult struct {
+	ID string
+	//Link is the payment link after the change, nil when Err is set
+	Link *PaymentLink
+	//Changed is false when the link already had the wanted state
+	Changed bool
+	Err     error
+}
+
+func (s *PaymentLinkService) SetActiveBulk(ids []string, active bool, concurrency int) []PaymentLinkStatusResult {
+	return s.SetActiveBulkWithContext(context.Background(), ids, active, concurrency)
+}
+
+// SetActiveBulkWithContext activates or deactivates every link in ids with at
+// most concurrency requests at once, defaulting to 4. Results are in the
+// order of ids.
+func (s *PaymentLinkService) SetActiveBulkWithContext(ctx context.Context, ids []string, active bool, concurrency int) []PaymentLinkStatusResult {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	results := make([]PaymentLinkStatusResult, len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(ids); j++ {
+				results[j] = PaymentLinkStatusResult{ID: ids[j], Err: ctx.Err()}
+			}
+			wg.Wait()
+			return results
+		}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer func() { <-sem; wg.Done() }()
+			resp, changed, err := s.setActive(ctx, id, active)
+			results[i] = PaymentLinkStatusResult{ID: id, Changed: changed, Err: err}
+			if err == nil {
+				results[i].Link = &resp.Data
+			}
+		}(i, id)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,119 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newPaymentLinkStatusServer serves Get and ToggleStatus for links whose
+// active state is kept in active.
+func newPaymentLinkStatusServer(t *testing.T, active map[string]bool) (*Client, func() int) {
+	var mu sync.Mutex
+	var toggles int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		id := parts[1]
+		mu.Lock()
+		defer mu.Unlock()
+		state, ok := active[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":"error","message":"payment link not found"}`))
+			return
+		}
+		if r.Method == http.MethodPatch {
+			state = !state
+			active[id] = state
+			toggles++
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"name":%q,"active":%t}}`, id, state)
+	})
+	return client, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return toggles
+	}
+}
+
+func TestPaymentLinkService_Activate(t *testing.T) {
+	client, toggles := newPaymentLinkStatusServer(t, map[string]bool{"on": true, "off": false})
+
+	got, err := client.PaymentLink.Activate("on")
+	assert.NoError(t, err)
+	assert.True(t, got.Data.Active)
+	assert.Equal(t, 0, toggles())
+
+	got, err = client.PaymentLink.Activate("off")
+	assert.NoError(t, err)
+	assert.True(t, got.Data.Active)
+	assert.Equal(t, 1, toggles())
+
+	got, err = client.PaymentLink.Deactivate("off")
+	assert.NoError(t, err)
+	assert.False(t, got.Data.Active)
+	assert.Equal(t, 2, toggles())
+
+	_, err = client.PaymentLink.Deactivate("")
+	assert.Error(t, err)
+}
+
+func TestPaymentLinkService_ActivateRetoggles(t *testing.T) {
+	// An operator toggles the link at the same time, undoing our toggle.
+	active, toggles := false, 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			toggles++
+			if toggles > 1 {
+				active = !active
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"active":%t}}`, active)
+	})
+
+	got, err := client.PaymentLink.Activate("link")
+	assert.NoError(t, err)
+	assert.True(t, got.Data.Active)
+	assert.Equal(t, 2, toggles)
+}
+
+func TestPaymentLinkService_ActivateRereads(t *testing.T) {
+	// Our toggle reports a stale state, but the link is already active when
+	// read again, so it must not be toggled back.
+	active, toggles := false, 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			toggles++
+			active = !active
+			_, _ = w.Write([]byte(`{"status":"success","data":{"active":false}}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"active":%t}}`, active)
+	})
+
+	got, err := client.PaymentLink.Activate("link")
+	assert.NoError(t, err)
+	assert.True(t, got.Data.Active)
+	assert.Equal(t, 1, toggles)
+}
+
+func TestPaymentLinkService_SetActiveBulk(t *testing.T) {
+	client, toggles := newPaymentLinkStatusServer(t, map[string]bool{"a": true, "b": false, "c": true})
+
+	results := client.PaymentLink.SetActiveBulkWithContext(context.Background(), []string{"a", "b", "missing", "c"}, false, 2)
+	assert.Len(t, results, 4)
+	assert.Equal(t, "a", results[0].ID)
+	assert.True(t, results[0].Changed)
+	assert.False(t, results[0].Link.Active)
+	assert.False(t, results[1].Changed)
+	assert.NoError(t, results[1].Err)
+	assert.Error(t, results[2].Err)
+	assert.Nil(t, results[2].Link)
+	assert.True(t, results[3].Changed)
+	assert.Equal(t, 2, toggles())
+}
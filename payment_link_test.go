@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -29,7 +31,7 @@ func TestPaymentLinkService_Create(t *testing.T) {
 					Name:            "Astro payment link",
 					Description:     "Testing the payment link for my iphone 14",
 					PaymentLinkType: FixedPrice,
-					RequestedInfo:   []string{"name", "email", "phone"},
+					RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 					LocalAmount:     5000,
 					LocalCurrency:   "NGN",
 				},
@@ -50,7 +52,7 @@ func TestPaymentLinkService_Create(t *testing.T) {
 					Name:            "Astro NGO",
 					Description:     "Raising money to buy a dog",
 					PaymentLinkType: Donation,
-					RequestedInfo:   []string{"name", "email", "phone"},
+					RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 					LocalAmount:     5000,
 					LocalCurrency:   "NGN",
 				},
@@ -68,7 +70,7 @@ func TestPaymentLinkService_Create(t *testing.T) {
 					Name:            "Astro NGO",
 					Description:     "Raising money to buy a dog",
 					PaymentLinkType: FixedPrice,
-					RequestedInfo:   []string{"name", "email", "phone"},
+					RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 					LocalAmount:     5000,
 					LocalCurrency:   "ASTROPCOIN",
 				},
@@ -111,7 +113,7 @@ func TestPaymentLinkService_CreateCharge(t *testing.T) {
 						Name:            "Astro payment link",
 						Description:     "Testing the payment link for my iphone 14",
 						PaymentLinkType: FixedPrice,
-						RequestedInfo:   []string{"name", "email", "phone"},
+						RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 						LocalAmount:     5000,
 						LocalCurrency:   "NGN",
 					})
@@ -168,7 +170,7 @@ func TestPaymentLinkService_Delete(t *testing.T) {
 						Name:            "Astro payment link",
 						Description:     "Testing the payment link for my iphone 14",
 						PaymentLinkType: FixedPrice,
-						RequestedInfo:   []string{"name", "email", "phone"},
+						RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 						LocalAmount:     5000,
 						LocalCurrency:   "NGN",
 					})
@@ -215,7 +217,7 @@ func TestPaymentLinkService_Get(t *testing.T) {
 						Name:            "Astro payment link",
 						Description:     "Testing the payment link for my iphone 14",
 						PaymentLinkType: FixedPrice,
-						RequestedInfo:   []string{"name", "email", "phone"},
+						RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 						LocalAmount:     5000,
 						LocalCurrency:   "NGN",
 					})
@@ -317,7 +319,7 @@ func TestPaymentLinkService_ToggleStatus(t *testing.T) {
 						Name:            "Astro payment link",
 						Description:     "Testing the payment link for my iphone 14",
 						PaymentLinkType: FixedPrice,
-						RequestedInfo:   []string{"name", "email", "phone"},
+						RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 						LocalAmount:     5000,
 						LocalCurrency:   "NGN",
 					})
@@ -368,7 +370,7 @@ func TestPaymentLinkService_Update(t *testing.T) {
 						Name:            "Astro payment link",
 						Description:     "Testing the payment link for my iphone 14",
 						PaymentLinkType: FixedPrice,
-						RequestedInfo:   []string{"name", "email", "phone"},
+						RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 						LocalAmount:     5000,
 						LocalCurrency:   "NGN",
 					})
@@ -381,7 +383,7 @@ func TestPaymentLinkService_Update(t *testing.T) {
 					Name:            "Check",
 					Description:     "Testing the payment link for my iphone 14",
 					PaymentLinkType: FixedPrice,
-					RequestedInfo:   []string{"name", "email", "phone"},
+					RequestedInfo:   []RequestedInfoField{"name", "email", "phone_number"},
 					LocalAmount:     5000,
 					LocalCurrency:   "NGN",
 				},
@@ -400,3 +402,34 @@ func TestPaymentLinkService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestPaymentLinkService_Patch(t *testing.T) {
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/payment_links/link_1", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(`{"status":"success","data":{"description":"New description"}}`))
+	})
+
+	description := "New description"
+	got, err := client.PaymentLink.Patch("link_1", &PaymentLinkUpdateRequest{Description: &description})
+	assert.NoError(t, err)
+	assert.Equal(t, "New description", got.Data.Description)
+	assert.JSONEq(t, `{"description":"New description"}`, body)
+
+	// An empty, non-nil slice clears the requested information.
+	_, err = client.PaymentLink.Patch("link_1", &PaymentLinkUpdateRequest{RequestedInfo: []RequestedInfoField{}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"requested_info":[]}`, body)
+
+	amount := 5000.0
+	_, err = client.PaymentLink.Patch("link_1", &PaymentLinkUpdateRequest{LocalAmount: &amount, RequestedInfo: []RequestedInfoField{RequestEmail}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"local_amount":"5000","requested_info":["email"]}`, body)
+
+	_, err = client.PaymentLink.Patch("", &PaymentLinkUpdateRequest{Description: &description})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// Package prometheus exposes Prometheus metrics for calls made by a Busha
+// Commerce Client.
+//
+//	collector := prometheus.NewCollector(prometheus.Options{})
+//	registry.MustRegister(collector)
+//	collector.Instrument(client)
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	commerce "github.com/bushaHQ/busha-commerce-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options configures a Collector.
+type Options struct {
+	//Namespace prefixes every metric name, defaults to "busha_commerce"
+	Namespace string
+	//Buckets are the latency histogram buckets in seconds,
+	//defaults to prometheus.DefBuckets
+	Buckets []float64
+	//ConstLabels are added to every metric i.e. {"service": "checkout"}
+	ConstLabels prometheus.Labels
+}
+
+// Collector records request counts, errors, latencies and retries for
+// every operation of the clients it instruments.
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+// NewCollector returns a Collector. It must be registered with a
+// prometheus.Registerer before its metrics are exported.
+func NewCollector(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "busha_commerce"
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_total",
+			Help:        "Number of Busha Commerce API calls by operation, status code and key mode.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"operation", "code", "mode"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "errors_total",
+			Help:        "Number of failed Busha Commerce API calls by operation, error name and key mode.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"operation", "error", "mode"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of Busha Commerce API calls by operation.",
+			Buckets:     opts.Buckets,
+			ConstLabels: opts.ConstLabels,
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "retries_total",
+			Help:        "Number of retried Busha Commerce API calls by operation.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"operation"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.latency.Describe(ch)
+	c.retries.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.latency.Collect(ch)
+	c.retries.Collect(ch)
+}
+
+// Instrument adds the Collector's middleware to client.
+func (c *Collector) Instrument(client *commerce.Client) {
+	client.Use(c.Middleware)
+}
+
+// Middleware records metrics for each call passing through next.
+func (c *Collector) Middleware(next commerce.Handler) commerce.Handler {
+	return func(ctx context.Context, req *commerce.Request) (*commerce.Result, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		c.latency.WithLabelValues(req.Operation).Observe(time.Since(start).Seconds())
+
+		code := "error"
+		if res != nil {
+			if res.StatusCode != 0 {
+				code = strconv.Itoa(res.StatusCode)
+			}
+			if res.Retries > 0 {
+				c.retries.WithLabelValues(req.Operation).Add(float64(res.Retries))
+			}
+		}
+		c.requests.WithLabelValues(req.Operation, code, string(req.Mode)).Inc()
+
+		if err != nil {
+			c.errors.WithLabelValues(req.Operation, errorName(err), string(req.Mode)).Inc()
+		}
+		return res, err
+	}
+}
+
+// errorName returns the ErrResponse name, or a coarse class for other errors.
+func errorName(err error) string {
+	var e commerce.ErrResponse
+	switch {
+	case errors.As(err, &e) && e.Errors.Name != "":
+		return e.Errors.Name
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	case commerce.IsCircuitOpen(err):
+		return "circuit_open"
+	default:
+		return "transport"
+	}
+}
@@ -0,0 +1,81 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	commerce "github.com/bushaHQ/busha-commerce-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme, r.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestCollector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"name":"NotFound","message":"Charge not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	client, err := commerce.New("test_key", &http.Client{Transport: rewriteTransport{target}})
+	assert.NoError(t, err)
+
+	collector := NewCollector(Options{})
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(t, registry.Register(collector))
+	collector.Instrument(client)
+
+	_, err = client.Charge.GetWithContext(context.Background(), "found")
+	assert.NoError(t, err)
+	_, err = client.Charge.GetWithContext(context.Background(), "missing")
+	assert.Error(t, err)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.requests.WithLabelValues(commerce.OpChargeGet, "200", "test")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.requests.WithLabelValues(commerce.OpChargeGet, "404", "test")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.errors.WithLabelValues(commerce.OpChargeGet, "NotFound", "test")))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "busha_commerce_request_duration_seconds"))
+}
+
+func TestCollector_CircuitOpen(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"error":{"name":"BadGateway","message":"Bad gateway"}}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	client, err := commerce.New("test_key", &http.Client{Transport: rewriteTransport{target}})
+	assert.NoError(t, err)
+	client.SetCircuitBreaker(commerce.NewCircuitBreaker(commerce.CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute}))
+
+	collector := NewCollector(Options{})
+	collector.Instrument(client)
+
+	_, err = client.Charge.GetWithContext(context.Background(), "id")
+	assert.False(t, commerce.IsCircuitOpen(err))
+	_, err = client.Charge.GetWithContext(context.Background(), "id")
+	assert.True(t, commerce.IsCircuitOpen(err))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.errors.WithLabelValues(commerce.OpChargeGet, "BadGateway", "test")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.errors.WithLabelValues(commerce.OpChargeGet, "circuit_open", "test")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.errors.WithLabelValues(commerce.OpChargeGet, "transport", "test")))
+}
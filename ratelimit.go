@@ -0,0 +1,184 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup identifies a family of API endpoints that share a rate limit.
+type EndpointGroup string
+
+const (
+	ChargeEndpoints      EndpointGroup = "charges"
+	EventEndpoints       EndpointGroup = "events"
+	InvoiceEndpoints     EndpointGroup = "invoices"
+	PaymentLinkEndpoints EndpointGroup = "payment_links"
+	AddressEndpoints     EndpointGroup = "addresses"
+)
+
+const defaultRateLimitRetries = 3
+
+// RateLimiter is a token bucket used to throttle outbound requests.
+// It also adapts to the rate-limit headers returned by the API, pausing
+// until the advertised reset time once the remaining quota is exhausted.
+type RateLimiter struct {
+	//MaxRetries is the number of times a request rejected with
+	//429 Too Many Requests is retried after waiting for the limit to reset.
+	MaxRetries int
+
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing perSecond requests per second
+// with bursts of up to burst requests.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		MaxRetries: defaultRateLimitRetries,
+		rate:       perSecond,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		now:        time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		d := l.reserve()
+		if d <= 0 {
+			return nil
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// the caller should wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Observe adapts the limiter to the rate-limit headers of a response.
+// It returns how long to wait before retrying when the request was rejected
+// with 429 Too Many Requests.
+func (l *RateLimiter) Observe(status int, header http.Header) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var until time.Time
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil && remaining <= 0 {
+		until = parseRateLimitReset(header.Get("X-RateLimit-Reset"), now)
+	}
+	if status == http.StatusTooManyRequests {
+		if t := parseRetryAfter(header.Get("Retry-After"), now); t.After(until) {
+			until = t
+		}
+		if until.IsZero() {
+			until = now.Add(time.Second)
+		}
+	}
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+		l.tokens = 0
+	}
+	if status != http.StatusTooManyRequests {
+		return 0
+	}
+	return until.Sub(now)
+}
+
+// parseRateLimitReset accepts either a unix timestamp or a number of seconds.
+func parseRateLimitReset(v string, now time.Time) time.Time {
+	n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	if n > 1e9 {
+		return time.Unix(n, 0)
+	}
+	return now.Add(time.Duration(n) * time.Second)
+}
+
+// parseRetryAfter accepts either a number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Time {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		return now.Add(time.Duration(n) * time.Second)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+func endpointGroup(path string) EndpointGroup {
+	p := strings.TrimPrefix(path, "/")
+	if i := strings.IndexAny(p, "/?"); i >= 0 {
+		p = p[:i]
+	}
+	return EndpointGroup(p)
+}
+
+// SetRateLimiter throttles requests to the given endpoint groups with l.
+// When no group is given, l applies to every endpoint without its own limiter.
+func (c *Client) SetRateLimiter(l *RateLimiter, groups ...EndpointGroup) {
+	if len(groups) == 0 {
+		c.rateLimiter = l
+		return
+	}
+	if c.rateLimiters == nil {
+		c.rateLimiters = make(map[EndpointGroup]*RateLimiter)
+	}
+	for _, g := range groups {
+		c.rateLimiters[g] = l
+	}
+}
+
+func (c *Client) limiterFor(path string) *RateLimiter {
+	if l, ok := c.rateLimiters[endpointGroup(path)]; ok {
+		return l
+	}
+	return c.rateLimiter
+}
@@ -0,0 +1,92 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewRateLimiter(1, 2)
+	l.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Second, l.reserve())
+
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.Canceled)
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		want   time.Duration
+		paused time.Duration
+	}{
+		{
+			name:   "quota available",
+			status: http.StatusOK,
+			header: http.Header{"X-Ratelimit-Remaining": {"5"}, "X-Ratelimit-Reset": {"30"}},
+		},
+		{
+			name:   "quota exhausted",
+			status: http.StatusOK,
+			header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"30"}},
+			paused: 30 * time.Second,
+		},
+		{
+			name:   "too many requests with retry after",
+			status: http.StatusTooManyRequests,
+			header: http.Header{"Retry-After": {"5"}},
+			want:   5 * time.Second,
+			paused: 5 * time.Second,
+		},
+		{
+			name:   "too many requests with unix reset",
+			status: http.StatusTooManyRequests,
+			header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1700000010"}},
+			want:   10 * time.Second,
+			paused: 10 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewRateLimiter(10, 10)
+			l.now = func() time.Time { return now }
+			assert.Equal(t, tt.want, l.Observe(tt.status, tt.header))
+			assert.Equal(t, tt.paused, l.reserve())
+		})
+	}
+}
+
+func TestClient_RateLimitRetry(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"name":"RateLimited","message":"Too many requests"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+	})
+	client.SetRateLimiter(NewRateLimiter(100, 1), ChargeEndpoints)
+
+	got, err := client.Charge.Get("a-charge")
+	assert.NoError(t, err)
+	assert.Equal(t, Success, got.Status)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
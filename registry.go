@@ -0,0 +1,139 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gobuffalo/uuid"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Business is a Busha business managed through a Registry.
+type Business struct {
+	//ID is the business ID, as returned in Charge.BusinessId and Event.BusinessId
+	ID string
+	//Name is an optional label i.e. "Busha Nigeria"
+	Name string
+	//Client is the Client authenticated with the business's secret key
+	Client *Client
+	//WebhookSecret is the secret used to verify the business's webhooks
+	WebhookSecret string
+}
+
+// ErrUnknownBusiness is returned when no Client is registered for a business.
+type ErrUnknownBusiness struct {
+	ID string
+}
+
+func (e ErrUnknownBusiness) Error() string {
+	if e.ID == "" {
+		return "no business ID provided"
+	}
+	return fmt.Sprintf("no client registered for business %s", e.ID)
+}
+
+// Registry holds the Clients of several businesses and routes calls by business ID.
+type Registry struct {
+	mu         sync.RWMutex
+	businesses map[string]Business
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{businesses: make(map[string]Business)}
+}
+
+func normalizeBusinessID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+// Register adds or replaces a business.
+func (r *Registry) Register(b Business) error {
+	if normalizeBusinessID(b.ID) == "" {
+		return errors.New("no business ID provided")
+	}
+	if b.Client == nil {
+		return fmt.Errorf("no client provided for business %s", b.ID)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.businesses[normalizeBusinessID(b.ID)] = b
+	return nil
+}
+
+// Remove deletes a business from the registry.
+func (r *Registry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.businesses, normalizeBusinessID(id))
+}
+
+// Get returns the business registered under id.
+func (r *Registry) Get(id string) (Business, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.businesses[normalizeBusinessID(id)]
+	if !ok {
+		return Business{}, ErrUnknownBusiness{ID: id}
+	}
+	return b, nil
+}
+
+// Businesses returns every registered business ordered by ID.
+func (r *Registry) Businesses() []Business {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Business, 0, len(r.businesses))
+	for _, b := range r.businesses {
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+type businessKey struct{}
+
+// WithBusiness returns a copy of ctx carrying the business ID used by Registry.Client.
+func WithBusiness(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, businessKey{}, id)
+}
+
+// BusinessFromContext returns the business ID stored in ctx by WithBusiness.
+func BusinessFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(businessKey{}).(string)
+	return id, ok
+}
+
+// Client returns the Client of the business whose ID is stored in ctx.
+func (r *Registry) Client(ctx context.Context) (*Client, error) {
+	id, _ := BusinessFromContext(ctx)
+	b, err := r.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return b.Client, nil
+}
+
+// ForEvent returns the business an event belongs to, so webhook handlers
+// can pick the right secret and Client.
+func (r *Registry) ForEvent(e *Event) (Business, error) {
+	if e == nil {
+		return Business{}, errors.New("no event provided")
+	}
+	id := e.BusinessId
+	if id == "" && e.Data.BusinessId != uuid.Nil {
+		id = e.Data.BusinessId.String()
+	}
+	return r.Get(id)
+}
+
+// WebhookSecret returns the webhook secret registered for a business.
+func (r *Registry) WebhookSecret(id string) (string, error) {
+	b, err := r.Get(id)
+	if err != nil {
+		return "", err
+	}
+	return b.WebhookSecret, nil
+}
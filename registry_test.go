@@ -0,0 +1,40 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gobuffalo/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	ng, _ := New("test_ng", nil)
+	gh, _ := New("test_gh", nil)
+	ngID := uuid.Must(uuid.NewV4())
+
+	r := NewRegistry()
+	assert.NoError(t, r.Register(Business{ID: ngID.String(), Name: "Nigeria", Client: ng, WebhookSecret: "ng-secret"}))
+	assert.NoError(t, r.Register(Business{ID: "gh-business", Name: "Ghana", Client: gh, WebhookSecret: "gh-secret"}))
+	assert.Error(t, r.Register(Business{ID: "empty"}))
+
+	got, err := r.Client(WithBusiness(context.Background(), "GH-business"))
+	assert.NoError(t, err)
+	assert.Same(t, gh, got)
+
+	_, err = r.Client(context.Background())
+	assert.Equal(t, ErrUnknownBusiness{}, err)
+
+	b, err := r.ForEvent(&Event{Data: EventData{BusinessId: ngID}})
+	assert.NoError(t, err)
+	assert.Equal(t, "ng-secret", b.WebhookSecret)
+
+	secret, err := r.WebhookSecret("gh-business")
+	assert.NoError(t, err)
+	assert.Equal(t, "gh-secret", secret)
+
+	r.Remove("gh-business")
+	_, err = r.Get("gh-business")
+	assert.Equal(t, ErrUnknownBusiness{ID: "gh-business"}, err)
+	assert.Len(t, r.Businesses(), 1)
+}
@@ -0,0 +1,145 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ReminderStore remembers when each invoice was last reminded.
+type ReminderStore interface {
+	LastReminded(ctx context.Context, invoiceID string) (time.Time, error)
+	SetReminded(ctx context.Context, invoiceID string, at time.Time) error
+}
+
+// MemoryReminderStore is a ReminderStore kept in memory.
+type MemoryReminderStore struct {
+	mu       sync.Mutex
+	reminded map[string]time.Time
+}
+
+// NewMemoryReminderStore returns an empty MemoryReminderStore.
+func NewMemoryReminderStore() *MemoryReminderStore {
+	return &MemoryReminderStore{reminded: make(map[string]time.Time)}
+}
+
+func (m *MemoryReminderStore) LastReminded(_ context.Context, invoiceID string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.reminded[invoiceID], nil
+}
+
+func (m *MemoryReminderStore) SetReminded(_ context.Context, invoiceID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reminded[invoiceID] = at
+	return nil
+}
+
+// InvoiceReminder resends unpaid invoices that are overdue or due soon,
+// at most once per Cadence for each invoice.
+type InvoiceReminder struct {
+	//DueSoon reminds invoices due within this window, defaults to 3 days
+	DueSoon time.Duration
+	//Cadence is the minimum time between two reminders of an invoice, defaults to 3 days
+	Cadence time.Duration
+	//PageSize is the number of invoices listed per page, defaults to 50
+	PageSize int64
+	//OnReminded is called after each resend attempt
+	OnReminded func(ctx context.Context, invoice *Invoice, err error)
+	//OnError is called with errors that stop a Tick, with the invoice ID
+	//when the error concerns one invoice
+	OnError func(id string, err error)
+
+	invoices *InvoiceService
+	store    ReminderStore
+	now      func() time.Time
+}
+
+// NewInvoiceReminder returns a reminder resending through invoices and
+// remembering reminders in store. A nil store keeps state in memory.
+func NewInvoiceReminder(invoices *InvoiceService, store ReminderStore) *InvoiceReminder {
+	if store == nil {
+		store = NewMemoryReminderStore()
+	}
+	return &InvoiceReminder{
+		DueSoon:  72 * time.Hour,
+		Cadence:  72 * time.Hour,
+		PageSize: 50,
+		invoices: invoices,
+		store:    store,
+		now:      time.Now,
+	}
+}
+
+// Due reports whether inv should be reminded at now, ignoring the cadence.
+func (r *InvoiceReminder) Due(inv *Invoice, now time.Time) bool {
+	if inv.DueDate == nil || !inv.Status.AwaitsPayment() {
+		return false
+	}
+	return now.Add(r.DueSoon).After(*inv.DueDate)
+}
+
+// Tick walks every invoice and resends those that are due. It returns the
+// number of invoices resent.
+func (r *InvoiceReminder) Tick(ctx context.Context) (int, error) {
+	now := r.now()
+	sent := 0
+	for page := int64(1); ; page++ {
+		resp, err := r.invoices.ListWithContext(ctx, ListParameters{Page: page, Limit: r.PageSize})
+		if err != nil {
+			r.reportError(ctx, "", err)
+			return sent, err
+		}
+		for _, inv := range resp.Data {
+			if !r.Due(inv, now) {
+				continue
+			}
+			last, err := r.store.LastReminded(ctx, inv.Id.String())
+			if err != nil {
+				r.reportError(ctx, inv.Id.String(), err)
+				return sent, err
+			}
+			if !last.IsZero() && now.Sub(last) < r.Cadence {
+				continue
+			}
+
+			_, err = r.invoices.ResendWithContext(ctx, inv.Id.String())
+			if err == nil {
+				sent++
+				err = r.store.SetReminded(ctx, inv.Id.String(), now)
+			}
+			if r.OnReminded != nil {
+				r.OnReminded(ctx, inv, err)
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return sent, ctxErr
+			}
+		}
+		if len(resp.Data) == 0 || page >= int64(resp.Pagination.TotalPages) {
+			return sent, nil
+		}
+	}
+}
+
+// reportError passes err to OnError unless it was caused by ctx ending.
+func (r *InvoiceReminder) reportError(ctx context.Context, id string, err error) {
+	if r.OnError != nil && ctx.Err() == nil {
+		r.OnError(id, err)
+	}
+}
+
+// Run calls Tick every interval until ctx is done. Errors are reported to
+// OnError and do not stop it.
+func (r *InvoiceReminder) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = r.Tick(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoiceReminder(t *testing.T) {
+	var resent []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/resend") {
+			resent = append(resent, strings.Split(r.URL.Path, "/")[2])
+			_, _ = w.Write([]byte(`{"status":"success"}`))
+			return
+		}
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte(`{"status":"success","pagination":{"total_pages":1},"data":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","pagination":{"page":1,"total_pages":1},"data":[
+			{"id":"11111111-1111-1111-1111-111111111111","status":"unpaid","due_date":"2026-01-01T00:00:00Z"},
+			{"id":"22222222-2222-2222-2222-222222222222","status":"paid","due_date":"2026-01-01T00:00:00Z"},
+			{"id":"33333333-3333-3333-3333-333333333333","status":"unpaid","due_date":"2026-03-01T00:00:00Z"},
+			{"id":"44444444-4444-4444-4444-444444444444","status":"unpaid","due_date":null}
+		]}`))
+	})
+
+	now := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	reminder := NewInvoiceReminder(client.Invoice, nil)
+	reminder.now = func() time.Time { return now }
+
+	sent, err := reminder.Tick(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"11111111-1111-1111-1111-111111111111"}, resent)
+
+	now = now.Add(24 * time.Hour)
+	sent, _ = reminder.Tick(context.Background())
+	assert.Equal(t, 0, sent)
+
+	now = now.Add(72 * time.Hour)
+	sent, _ = reminder.Tick(context.Background())
+	assert.Equal(t, 1, sent)
+}
+
+func TestInvoiceUpdateRequest_Validate(t *testing.T) {
+	email, amount := "not-an-email", -1.0
+	assertFieldErrors(t, (&InvoiceUpdateRequest{}).Validate(), []string{"request"})
+	assertFieldErrors(t, (&InvoiceUpdateRequest{CustomerEmail: &email, LocalAmount: &amount}).Validate(),
+		[]string{"customer_email", "local_amount"})
+
+	due := time.Now().Add(time.Hour)
+	assert.NoError(t, (&InvoiceUpdateRequest{DueDate: &due}).Validate())
+}
+
+func TestInvoiceReminder_RunContinuesAfterErrors(t *testing.T) {
+	var mu sync.Mutex
+	var lists int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/resend") {
+			_, _ = w.Write([]byte(`{"status":"success"}`))
+			return
+		}
+		mu.Lock()
+		lists++
+		first := lists == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error":{"name":"BadGateway","message":"Bad gateway"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","pagination":{"page":1,"total_pages":1},"data":[
+			{"id":"11111111-1111-1111-1111-111111111111","status":"unpaid","due_date":"2026-01-01T00:00:00Z"}
+		]}`))
+	})
+	reminder := NewInvoiceReminder(client.Invoice, nil)
+	reminder.now = func() time.Time { return time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC) }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var failed []string
+	reminder.OnError = func(id string, err error) { failed = append(failed, id) }
+	reminder.OnReminded = func(ctx context.Context, invoice *Invoice, err error) { cancel() }
+
+	err := reminder.Run(ctx, time.Millisecond)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{""}, failed)
+}
+
+func TestInvoiceService_Update(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/invoices/11111111-1111-1111-1111-111111111111", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"due_date":"2030-01-01T00:00:00Z"}`, string(body))
+		_, _ = w.Write([]byte(`{"status":"success","data":{"id":"11111111-1111-1111-1111-111111111111"}}`))
+	})
+
+	due := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := client.Invoice.Update("11111111-1111-1111-1111-111111111111", &InvoiceUpdateRequest{DueDate: &due})
+	assert.NoError(t, err)
+}
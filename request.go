@@ -45,14 +45,45 @@ type PaymentLinkRequest struct {
 	//PaymentLinkType is the type of the payment link i.e. donation or fixed_price
 	PaymentLinkType PaymentLinkType `json:"payment_link_type"`
 	//RequestedInfo is the requested information you'd want from the customer
-	//i.e. ["name", "email","phone_number]
-	RequestedInfo []string `json:"requested_info"`
+	//i.e. []RequestedInfoField{RequestName, RequestEmail, RequestPhoneNumber}
+	RequestedInfo []RequestedInfoField `json:"requested_info"`
 	//LocalAmount amount in the currency to be charged
 	LocalAmount float64 `json:"local_amount,string"`
 	//LocalCurrency currency of the charge i.e, NGN
 	LocalCurrency string `json:"local_currency"`
 }
 
+// PaymentLinkUpdateRequest changes an existing payment link. Only the fields
+// that are set are sent.
+type PaymentLinkUpdateRequest struct {
+	//Name is the name of the payment link
+	Name *string `json:"name,omitempty"`
+	//Description is the description of the payment link
+	Description *string `json:"description,omitempty"`
+	//PaymentLinkType is the type of the payment link i.e. donation or fixed_price
+	PaymentLinkType *PaymentLinkType `json:"payment_link_type,omitempty"`
+	//RequestedInfo replaces the requested information when not nil, an
+	//empty slice clears it
+	RequestedInfo []RequestedInfoField `json:"requested_info,omitempty"`
+	//LocalAmount amount in the currency to be charged
+	LocalAmount *float64 `json:"local_amount,string,omitempty"`
+	//LocalCurrency currency of the charge i.e, NGN
+	LocalCurrency *string `json:"local_currency,omitempty"`
+}
+
+// MarshalJSON sends an empty, non-nil RequestedInfo as [] so it can be cleared.
+func (r PaymentLinkUpdateRequest) MarshalJSON() ([]byte, error) {
+	type fields PaymentLinkUpdateRequest
+	var info *[]RequestedInfoField
+	if r.RequestedInfo != nil {
+		info = &r.RequestedInfo
+	}
+	return json.Marshal(struct {
+		fields
+		RequestedInfo *[]RequestedInfoField `json:"requested_info,omitempty"`
+	}{fields(r), info})
+}
+
 type InvoiceRequest struct {
 	//Name is the name of the invoice
 	Name string `json:"name"`
@@ -68,6 +99,27 @@ type InvoiceRequest struct {
 	Description string `json:"description"`
 	//DueDate is the date by which the invoice will be void
 	DueDate *time.Time `json:"due_date"`
+	//Meta set of key:value stored with the invoice, i.e. its line items
+	Meta json.RawMessage `json:"meta,omitempty"`
+}
+
+// InvoiceUpdateRequest changes an existing invoice. Only the fields that
+// are set are sent.
+type InvoiceUpdateRequest struct {
+	//Name is the name of the invoice
+	Name *string `json:"name,omitempty"`
+	//CustomerEmail is the email of the customer
+	CustomerEmail *string `json:"customer_email,omitempty"`
+	//CustomerName is the name of the customer
+	CustomerName *string `json:"customer_name,omitempty"`
+	//Description is the description of the invoice
+	Description *string `json:"description,omitempty"`
+	//LocalAmount amount in the currency to be charged
+	LocalAmount *float64 `json:"local_amount,string,omitempty"`
+	//LocalCurrency currency of the charge i.e, NGN
+	LocalCurrency *string `json:"local_currency,omitempty"`
+	//DueDate is the date by which the invoice will be void
+	DueDate *time.Time `json:"due_date,omitempty"`
 }
 
 type AddressRequest struct {
@@ -0,0 +1,169 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResolutionContext is why an unresolved charge is being resolved.
+type ResolutionContext string
+
+const (
+	ResolutionUnderpaid    ResolutionContext = "UNDERPAID"
+	ResolutionOverpaid     ResolutionContext = "OVERPAID"
+	ResolutionDelayed      ResolutionContext = "DELAYED"
+	ResolutionManualReview ResolutionContext = "MANUAL_REVIEW"
+)
+
+func (r ResolutionContext) valid() bool {
+	switch r {
+	case ResolutionUnderpaid, ResolutionOverpaid, ResolutionDelayed, ResolutionManualReview:
+		return true
+	}
+	return false
+}
+
+// Resolution describes who resolves a charge and why.
+type Resolution struct {
+	Context ResolutionContext
+	//ResolvedBy identifies the operator or system resolving the charge
+	ResolvedBy string
+	//Reason is a free-form note kept in the audit record
+	Reason string
+}
+
+// ResolutionRecord is the audit record written after a charge is resolved.
+type ResolutionRecord struct {
+	ChargeID   string            `json:"charge_id"`
+	Reference  string            `json:"reference"`
+	Context    ResolutionContext `json:"context"`
+	ResolvedBy string            `json:"resolved_by"`
+	Reason     string            `json:"reason"`
+	ResolvedAt time.Time         `json:"resolved_at"`
+}
+
+// ResolutionLog stores resolution audit records.
+type ResolutionLog interface {
+	Record(ctx context.Context, r ResolutionRecord) error
+}
+
+// MemoryResolutionLog is a ResolutionLog kept in memory.
+type MemoryResolutionLog struct {
+	mu      sync.Mutex
+	records []ResolutionRecord
+}
+
+func (l *MemoryResolutionLog) Record(_ context.Context, r ResolutionRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, r)
+	return nil
+}
+
+// Records returns the records written so far.
+func (l *MemoryResolutionLog) Records() []ResolutionRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]ResolutionRecord(nil), l.records...)
+}
+
+// SetResolutionLog sets where ChargeService.ResolveAs writes audit records.
+func (c *Client) SetResolutionLog(l ResolutionLog) {
+	c.resolutionLog = l
+}
+
+// ErrNotResolvable is returned for charges that are not eligible for resolution.
+type ErrNotResolvable struct {
+	Status string
+}
+
+func (e ErrNotResolvable) Error() string {
+	return fmt.Sprintf("charge with status %s cannot be resolved", strings.ToLower(e.Status))
+}
+
+// EligibleForResolution reports whether c can be resolved and, if so, the
+// resolution context suggested by its timeline and payments. Only
+// unresolved charges are eligible.
+func EligibleForResolution(c *Charge) (ResolutionContext, error) {
+	if status := c.Status(); status != ChargeStatusUnresolved {
+		return "", ErrNotResolvable{Status: status}
+	}
+
+	var expired bool
+	for _, t := range c.Timeline {
+		if strings.EqualFold(t.Status, ChargeStatusExpired) {
+			expired = true
+		}
+		if ctx := ResolutionContext(strings.ToUpper(t.Context)); ctx.valid() && strings.EqualFold(t.Status, ChargeStatusUnresolved) {
+			return ctx, nil
+		}
+	}
+	if expired {
+		return ResolutionDelayed, nil
+	}
+
+	var paid float64
+	for _, p := range c.Payments {
+		paid += p.LocalAmount
+	}
+	switch {
+	case paid < c.LocalAmount-underpaymentTolerance(c):
+		return ResolutionUnderpaid, nil
+	case paid > c.LocalAmount+overpaymentTolerance(c):
+		return ResolutionOverpaid, nil
+	default:
+		return ResolutionManualReview, nil
+	}
+}
+
+func underpaymentTolerance(c *Charge) float64 {
+	t := c.PaymentThreshold
+	if rel := t.UnderpaymentRelativeThreshold * c.LocalAmount; rel > t.UnderpaymentAbsoluteThreshold {
+		return rel
+	}
+	return t.UnderpaymentAbsoluteThreshold
+}
+
+func overpaymentTolerance(c *Charge) float64 {
+	t := c.PaymentThreshold
+	if rel := t.OverpaymentRelativeThreshold * c.LocalAmount; rel > t.OverpaymentAbsoluteThreshold {
+		return rel
+	}
+	return t.OverpaymentAbsoluteThreshold
+}
+
+func (s *ChargeService) ResolveAs(id string, r Resolution) (*ChargeResponse, error) {
+	return s.ResolveAsWithContext(context.Background(), id, r)
+}
+
+func (s *ChargeService) ResolveAsWithContext(ctx context.Context, id string, r Resolution) (*ChargeResponse, error) {
+	if !r.Context.valid() {
+		return nil, ValidationError{{Field: "context", Message: "is not a known resolution context"}}
+	}
+	if strings.TrimSpace(r.ResolvedBy) == "" {
+		return nil, ValidationError{{Field: "resolved_by", Message: "is required"}}
+	}
+	resp, err := s.ResolveWithContext(ctx, id, string(r.Context))
+	if err != nil {
+		return resp, err
+	}
+	if s.client.resolutionLog == nil {
+		return resp, nil
+	}
+	record := ResolutionRecord{
+		ChargeID:   strings.TrimSpace(id),
+		Reference:  resp.Data.Reference,
+		Context:    r.Context,
+		ResolvedBy: r.ResolvedBy,
+		Reason:     r.Reason,
+		ResolvedAt: time.Now().UTC(),
+	}
+	if err = s.client.resolutionLog.Record(ctx, record); err != nil {
+		return resp, errors.Join(errors.New("charge resolved but audit record failed"), err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,98 @@
+package busha_commerce_go
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEligibleForResolution(t *testing.T) {
+	unresolved := func(context string) []ChargeTimeline {
+		return []ChargeTimeline{{Status: ChargeStatusNew}, {Status: ChargeStatusUnresolved, Context: context}}
+	}
+	tests := []struct {
+		name    string
+		charge  Charge
+		want    ResolutionContext
+		wantErr error
+	}{
+		{
+			name:    "completed charge",
+			charge:  Charge{Timeline: []ChargeTimeline{{Status: ChargeStatusCompleted}}},
+			wantErr: ErrNotResolvable{Status: ChargeStatusCompleted},
+		},
+		{
+			name:   "context from timeline",
+			charge: Charge{Timeline: unresolved("overpaid")},
+			want:   ResolutionOverpaid,
+		},
+		{
+			name:   "paid after expiry",
+			charge: Charge{Timeline: []ChargeTimeline{{Status: ChargeStatusExpired}, {Status: ChargeStatusUnresolved}}},
+			want:   ResolutionDelayed,
+		},
+		{
+			name: "underpaid beyond threshold",
+			charge: Charge{
+				Timeline:         unresolved(""),
+				LocalAmount:      1000,
+				PaymentThreshold: PaymentThreshold{UnderpaymentAbsoluteThreshold: 10},
+				Payments:         []ChargePayment{{LocalAmount: 900}},
+			},
+			want: ResolutionUnderpaid,
+		},
+		{
+			name: "overpaid beyond relative threshold",
+			charge: Charge{
+				Timeline:         unresolved(""),
+				LocalAmount:      1000,
+				PaymentThreshold: PaymentThreshold{OverpaymentRelativeThreshold: 0.05},
+				Payments:         []ChargePayment{{LocalAmount: 600}, {LocalAmount: 500}},
+			},
+			want: ResolutionOverpaid,
+		},
+		{
+			name: "within thresholds",
+			charge: Charge{
+				Timeline:    unresolved(""),
+				LocalAmount: 1000,
+				Payments:    []ChargePayment{{LocalAmount: 1000}},
+			},
+			want: ResolutionManualReview,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EligibleForResolution(&tt.charge)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChargeService_ResolveAs(t *testing.T) {
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"reference":"ref-1"}}`))
+	})
+	log := &MemoryResolutionLog{}
+	client.SetResolutionLog(log)
+
+	_, err := client.Charge.ResolveAs("charge-id", Resolution{Context: ResolutionUnderpaid})
+	assertFieldErrors(t, err, []string{"resolved_by"})
+
+	_, err = client.Charge.ResolveAs("charge-id", Resolution{Context: ResolutionUnderpaid, ResolvedBy: "ops@shop", Reason: "customer paid fees"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"context":"UNDERPAID"}`, body)
+
+	records := log.Records()
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "charge-id", records[0].ChargeID)
+		assert.Equal(t, "ref-1", records[0].Reference)
+		assert.Equal(t, "ops@shop", records[0].ResolvedBy)
+	}
+}
@@ -0,0 +1,395 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BillingInterval is the unit of a plan's billing period.
+type BillingInterval string
+
+const (
+	Daily   BillingInterval = "day"
+	Weekly  BillingInterval = "week"
+	Monthly BillingInterval = "month"
+	Yearly  BillingInterval = "year"
+)
+
+// Plan is a recurring price customers subscribe to.
+type Plan struct {
+	ID       string
+	Name     string
+	Amount   float64
+	Currency string
+	Interval BillingInterval
+	//IntervalCount is the number of intervals per period, defaults to 1
+	IntervalCount int
+	//DueAfter is how long customers have to pay each invoice, defaults to 7 days
+	DueAfter time.Duration
+}
+
+// boundary returns the start of period k of a subscription anchored at
+// anchor. Each boundary is computed from the anchor rather than from the
+// previous one, so a subscription started on Jan 31 bills on Feb 28 and
+// then Mar 31.
+func (p Plan) boundary(anchor time.Time, k int) time.Time {
+	n := p.IntervalCount
+	if n <= 0 {
+		n = 1
+	}
+	switch p.Interval {
+	case Daily:
+		return anchor.AddDate(0, 0, k*n)
+	case Weekly:
+		return anchor.AddDate(0, 0, 7*k*n)
+	case Yearly:
+		return addMonths(anchor, 12*k*n)
+	default:
+		return addMonths(anchor, k*n)
+	}
+}
+
+// addMonths adds months to t, clamping the day to the end of the month.
+func addMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// SubscriptionStatus is the state of a subscription.
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive    SubscriptionStatus = "active"
+	SubscriptionCancelled SubscriptionStatus = "cancelled"
+)
+
+// Subscription bills a customer for a plan every period.
+type Subscription struct {
+	ID            string             `json:"id"`
+	PlanID        string             `json:"plan_id"`
+	CustomerName  string             `json:"customer_name"`
+	CustomerEmail string             `json:"customer_email"`
+	Status        SubscriptionStatus `json:"status"`
+	//PeriodStart and PeriodEnd bound the period covered by the latest invoice
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	//NextInvoiceAt is when the next period is invoiced
+	NextInvoiceAt time.Time `json:"next_invoice_at"`
+	//Anchor is the start of the first period on the current plan interval
+	Anchor time.Time `json:"anchor"`
+	//Periods is the number of periods invoiced since Anchor
+	Periods int `json:"periods"`
+	//CancelAtPeriodEnd stops billing once the current period ends
+	CancelAtPeriodEnd bool `json:"cancel_at_period_end"`
+	//Proration is added to the next invoice, negative for a credit
+	Proration  float64  `json:"proration"`
+	InvoiceIDs []string `json:"invoice_ids"`
+}
+
+// SubscriptionStore persists subscription schedules.
+type SubscriptionStore interface {
+	GetSubscription(ctx context.Context, id string) (Subscription, error)
+	SaveSubscription(ctx context.Context, s Subscription) error
+	//DueSubscriptions returns active subscriptions whose NextInvoiceAt is not after now
+	DueSubscriptions(ctx context.Context, now time.Time) ([]Subscription, error)
+}
+
+// ErrSubscriptionNotFound is returned by stores for unknown subscriptions.
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// ErrSubscriptionCancelled is returned when changing a cancelled subscription.
+var ErrSubscriptionCancelled = errors.New("subscription is cancelled")
+
+// MemorySubscriptionStore is a SubscriptionStore kept in memory.
+type MemorySubscriptionStore struct {
+	mu   sync.Mutex
+	subs map[string]Subscription
+}
+
+// NewMemorySubscriptionStore returns an empty MemorySubscriptionStore.
+func NewMemorySubscriptionStore() *MemorySubscriptionStore {
+	return &MemorySubscriptionStore{subs: make(map[string]Subscription)}
+}
+
+func (m *MemorySubscriptionStore) GetSubscription(_ context.Context, id string) (Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.subs[id]
+	if !ok {
+		return Subscription{}, ErrSubscriptionNotFound
+	}
+	s.InvoiceIDs = append([]string(nil), s.InvoiceIDs...)
+	return s, nil
+}
+
+func (m *MemorySubscriptionStore) SaveSubscription(_ context.Context, s Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s.InvoiceIDs = append([]string(nil), s.InvoiceIDs...)
+	m.subs[s.ID] = s
+	return nil
+}
+
+func (m *MemorySubscriptionStore) DueSubscriptions(_ context.Context, now time.Time) ([]Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Subscription
+	for _, s := range m.subs {
+		if s.Status == SubscriptionActive && !s.NextInvoiceAt.After(now) {
+			s.InvoiceIDs = append([]string(nil), s.InvoiceIDs...)
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// SubscriptionEngine invoices subscriptions at each period boundary. Periods
+// are billed in advance: the invoice for a period is created when it starts.
+// Call Tick regularly, i.e. from cron.
+type SubscriptionEngine struct {
+	//OnInvoice is called for each invoice created by Tick
+	OnInvoice func(ctx context.Context, s Subscription, invoice *Invoice)
+	//OnError is called with errors met while billing a subscription during
+	//Tick, with an empty id when listing due subscriptions fails
+	OnError func(id string, err error)
+
+	invoices *InvoiceService
+	store    SubscriptionStore
+	plans    map[string]Plan
+	mu       sync.RWMutex
+	now      func() time.Time
+}
+
+// NewSubscriptionEngine returns an engine creating invoices through invoices
+// and keeping schedules in store. A nil store keeps them in memory.
+func NewSubscriptionEngine(invoices *InvoiceService, store SubscriptionStore) *SubscriptionEngine {
+	if store == nil {
+		store = NewMemorySubscriptionStore()
+	}
+	return &SubscriptionEngine{
+		invoices: invoices,
+		store:    store,
+		plans:    make(map[string]Plan),
+		now:      time.Now,
+	}
+}
+
+// AddPlan registers a plan.
+func (e *SubscriptionEngine) AddPlan(p Plan) error {
+	v := new(validator)
+	v.required("id", p.ID)
+	v.required("name", p.Name)
+	v.required("currency", p.Currency)
+	if p.Amount <= 0 {
+		v.add("amount", "must be greater than zero")
+	}
+	switch p.Interval {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		v.add("interval", "is not a known billing interval")
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	if p.DueAfter <= 0 {
+		p.DueAfter = 7 * 24 * time.Hour
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.plans[p.ID] = p
+	return nil
+}
+
+func (e *SubscriptionEngine) plan(id string) (Plan, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	p, ok := e.plans[id]
+	if !ok {
+		return Plan{}, fmt.Errorf("plan %s not found", id)
+	}
+	return p, nil
+}
+
+// Subscribe starts s on its plan. The first period starts at start, or now
+// when start is zero, and is invoiced by the next Tick.
+func (e *SubscriptionEngine) Subscribe(ctx context.Context, s Subscription, start time.Time) (Subscription, error) {
+	if s.ID == "" {
+		return s, errors.New("no subscription ID provided")
+	}
+	if _, err := e.plan(s.PlanID); err != nil {
+		return s, err
+	}
+	if start.IsZero() {
+		start = e.now()
+	}
+	s.Status = SubscriptionActive
+	s.NextInvoiceAt, s.Anchor, s.Periods = start, start, 0
+	return s, e.store.SaveSubscription(ctx, s)
+}
+
+// ChangePlan moves a subscription to another plan at the given time. The
+// unused part of the current period is credited at the old price and
+// charged at the new price on the next invoice, so both plans must be
+// billed in the same currency.
+func (e *SubscriptionEngine) ChangePlan(ctx context.Context, id, planID string, at time.Time) (Subscription, error) {
+	s, err := e.store.GetSubscription(ctx, id)
+	if err != nil {
+		return s, err
+	}
+	if s.Status == SubscriptionCancelled {
+		return s, ErrSubscriptionCancelled
+	}
+	oldPlan, err := e.plan(s.PlanID)
+	if err != nil {
+		return s, err
+	}
+	newPlan, err := e.plan(planID)
+	if err != nil {
+		return s, err
+	}
+	if newPlan.Currency != oldPlan.Currency {
+		return s, fmt.Errorf("plan %s is billed in %s, not %s", newPlan.ID, newPlan.Currency, oldPlan.Currency)
+	}
+	if at.After(s.PeriodStart) && at.Before(s.PeriodEnd) {
+		unused := float64(s.PeriodEnd.Sub(at)) / float64(s.PeriodEnd.Sub(s.PeriodStart))
+		s.Proration = roundAmount(s.Proration + unused*(newPlan.Amount-oldPlan.Amount))
+	}
+	if oldPlan.Interval != newPlan.Interval || oldPlan.IntervalCount != newPlan.IntervalCount {
+		// Periods of the new plan run from the end of the current one.
+		s.Anchor, s.Periods = s.NextInvoiceAt, 0
+	}
+	s.PlanID = planID
+	return s, e.store.SaveSubscription(ctx, s)
+}
+
+// Cancel stops a subscription, immediately or once the current period ends.
+func (e *SubscriptionEngine) Cancel(ctx context.Context, id string, atPeriodEnd bool) (Subscription, error) {
+	s, err := e.store.GetSubscription(ctx, id)
+	if err != nil {
+		return s, err
+	}
+	if atPeriodEnd {
+		s.CancelAtPeriodEnd = true
+	} else {
+		s.Status = SubscriptionCancelled
+	}
+	return s, e.store.SaveSubscription(ctx, s)
+}
+
+// Tick invoices every subscription whose next period has started, catching
+// up on missed periods. Each invoice is created with an idempotency key
+// derived from the subscription and period, and the schedule is saved after
+// each one so an interrupted run can be resumed.
+func (e *SubscriptionEngine) Tick(ctx context.Context) ([]*Invoice, error) {
+	now := e.now()
+	due, err := e.store.DueSubscriptions(ctx, now)
+	if err != nil {
+		if e.OnError != nil {
+			e.OnError("", err)
+		}
+		return nil, err
+	}
+	var created []*Invoice
+	var errs []error
+	for _, s := range due {
+		invoices, err := e.bill(ctx, s, now)
+		created = append(created, invoices...)
+		if err != nil {
+			if e.OnError != nil {
+				e.OnError(s.ID, err)
+			}
+			errs = append(errs, fmt.Errorf("subscription %s: %w", s.ID, err))
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+func (e *SubscriptionEngine) bill(ctx context.Context, s Subscription, now time.Time) ([]*Invoice, error) {
+	var created []*Invoice
+	if s.Anchor.IsZero() {
+		s.Anchor, s.Periods = s.NextInvoiceAt, 0
+	}
+	for s.Status == SubscriptionActive && !s.NextInvoiceAt.After(now) {
+		if s.CancelAtPeriodEnd {
+			s.Status = SubscriptionCancelled
+			return created, e.store.SaveSubscription(ctx, s)
+		}
+		p, err := e.plan(s.PlanID)
+		if err != nil {
+			return created, err
+		}
+
+		start := s.NextInvoiceAt
+		end := p.boundary(s.Anchor, s.Periods+1)
+		amount := roundAmount(p.Amount + s.Proration)
+		s.Proration = 0
+		if amount <= 0 {
+			// The credit covers the whole period, carry what is left over.
+			s.Proration = amount
+		} else {
+			inv, err := e.createInvoice(ctx, s, p, start, end, amount, now)
+			if err != nil {
+				return created, err
+			}
+			s.InvoiceIDs = append(s.InvoiceIDs, inv.Id.String())
+			created = append(created, inv)
+			if e.OnInvoice != nil {
+				e.OnInvoice(ctx, s, inv)
+			}
+		}
+
+		s.PeriodStart, s.PeriodEnd, s.NextInvoiceAt = start, end, end
+		s.Periods++
+		if err = e.store.SaveSubscription(ctx, s); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+func (e *SubscriptionEngine) createInvoice(ctx context.Context, s Subscription, p Plan, start, end time.Time, amount float64, now time.Time) (*Invoice, error) {
+	issued := start
+	if issued.Before(now) {
+		issued = now
+	}
+	due := issued.Add(p.DueAfter)
+	req := &InvoiceRequest{
+		Name:          fmt.Sprintf("%s (%s - %s)", p.Name, start.Format("2 Jan 2006"), end.Format("2 Jan 2006")),
+		CustomerEmail: s.CustomerEmail,
+		CustomerName:  s.CustomerName,
+		LocalAmount:   amount,
+		LocalCurrency: p.Currency,
+		Description:   fmt.Sprintf("Subscription %s to %s", s.ID, p.Name),
+		DueDate:       &due,
+	}
+	key := fmt.Sprintf("subscription:%s:%d", s.ID, start.Unix())
+	resp, err := e.invoices.CreateWithContext(WithIdempotencyKey(ctx, key), req)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Run calls Tick every interval until ctx is done. Billing errors are
+// reported to OnError and do not stop it.
+func (e *SubscriptionEngine) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = e.Tick(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package busha_commerce_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedInvoice struct {
+	key string
+	req InvoiceRequest
+}
+
+func newSubscriptionTestClient(t *testing.T) (*Client, func() []recordedInvoice) {
+	var mu sync.Mutex
+	var got []recordedInvoice
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req InvoiceRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		got = append(got, recordedInvoice{key: r.Header.Get("Idempotency-Key"), req: req})
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"status":"success","data":{"id":"11111111-1111-1111-1111-111111111111"}}`))
+	})
+	return client, func() []recordedInvoice {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedInvoice(nil), got...)
+	}
+}
+
+func TestSubscriptionEngine_Tick(t *testing.T) {
+	client, invoices := newSubscriptionTestClient(t)
+	engine := NewSubscriptionEngine(client.Invoice, nil)
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+
+	assert.NoError(t, engine.AddPlan(Plan{ID: "basic", Name: "Basic", Amount: 3000, Currency: "NGN", Interval: Monthly}))
+	_, err := engine.Subscribe(context.Background(), Subscription{
+		ID: "sub_1", PlanID: "basic", CustomerName: "Ada", CustomerEmail: "ada@example.com",
+	}, now)
+	assert.NoError(t, err)
+
+	created, err := engine.Tick(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, created, 1)
+
+	created, _ = engine.Tick(context.Background())
+	assert.Len(t, created, 0)
+
+	// A missed run catches up on every period that started since.
+	now = time.Date(2030, 3, 5, 0, 0, 0, 0, time.UTC)
+	created, err = engine.Tick(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, created, 2)
+
+	got := invoices()
+	assert.Len(t, got, 3)
+	assert.Equal(t, "subscription:sub_1:1893456000", got[0].key)
+	assert.Equal(t, 3000.0, got[0].req.LocalAmount)
+	assert.Equal(t, "ada@example.com", got[0].req.CustomerEmail)
+
+	sub, _ := engine.store.GetSubscription(context.Background(), "sub_1")
+	assert.Equal(t, time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC), sub.PeriodStart)
+	assert.Equal(t, time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC), sub.NextInvoiceAt)
+	assert.Len(t, sub.InvoiceIDs, 3)
+}
+
+func TestSubscriptionEngine_ChangePlan(t *testing.T) {
+	client, invoices := newSubscriptionTestClient(t)
+	engine := NewSubscriptionEngine(client.Invoice, nil)
+	now := time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.NoError(t, engine.AddPlan(Plan{ID: "basic", Name: "Basic", Amount: 3000, Currency: "NGN", Interval: Monthly}))
+	assert.NoError(t, engine.AddPlan(Plan{ID: "pro", Name: "Pro", Amount: 6000, Currency: "NGN", Interval: Monthly}))
+	_, _ = engine.Subscribe(ctx, Subscription{ID: "sub_1", PlanID: "basic", CustomerEmail: "ada@example.com"}, now)
+	_, _ = engine.Tick(ctx)
+
+	// Upgrading half way through a 30 day period charges half the difference.
+	sub, err := engine.ChangePlan(ctx, "sub_1", "pro", time.Date(2030, 4, 16, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, 1500.0, sub.Proration)
+
+	now = time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	_, _ = engine.Tick(ctx)
+	got := invoices()
+	assert.Len(t, got, 2)
+	assert.Equal(t, 7500.0, got[1].req.LocalAmount)
+
+	_, err = engine.ChangePlan(ctx, "sub_1", "missing", now)
+	assert.Error(t, err)
+
+	// Prorating across currencies would mix amounts, so it is refused.
+	assert.NoError(t, engine.AddPlan(Plan{ID: "usd", Name: "USD", Amount: 10, Currency: "USD", Interval: Monthly}))
+	_, err = engine.ChangePlan(ctx, "sub_1", "usd", time.Date(2030, 5, 16, 0, 0, 0, 0, time.UTC))
+	assert.EqualError(t, err, "plan usd is billed in USD, not NGN")
+	sub, _ = engine.store.GetSubscription(ctx, "sub_1")
+	assert.Equal(t, "pro", sub.PlanID)
+	assert.Equal(t, 0.0, sub.Proration)
+
+	_, err = engine.Cancel(ctx, "sub_1", false)
+	assert.NoError(t, err)
+	_, err = engine.ChangePlan(ctx, "sub_1", "basic", now)
+	assert.ErrorIs(t, err, ErrSubscriptionCancelled)
+}
+
+func TestSubscriptionEngine_Cancel(t *testing.T) {
+	client, invoices := newSubscriptionTestClient(t)
+	engine := NewSubscriptionEngine(client.Invoice, nil)
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.NoError(t, engine.AddPlan(Plan{ID: "weekly", Name: "Weekly", Amount: 500, Currency: "NGN", Interval: Weekly}))
+	_, _ = engine.Subscribe(ctx, Subscription{ID: "sub_1", PlanID: "weekly", CustomerEmail: "ada@example.com"}, now)
+	_, _ = engine.Tick(ctx)
+
+	sub, err := engine.Cancel(ctx, "sub_1", true)
+	assert.NoError(t, err)
+	assert.Equal(t, SubscriptionActive, sub.Status)
+
+	now = now.AddDate(0, 0, 7)
+	created, err := engine.Tick(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, created, 0)
+	assert.Len(t, invoices(), 1)
+
+	sub, _ = engine.store.GetSubscription(ctx, "sub_1")
+	assert.Equal(t, SubscriptionCancelled, sub.Status)
+}
+
+func TestSubscriptionEngine_AddPlan(t *testing.T) {
+	engine := NewSubscriptionEngine(nil, nil)
+	assertFieldErrors(t, engine.AddPlan(Plan{Interval: "fortnight"}),
+		[]string{"id", "name", "currency", "amount", "interval"})
+	_, err := engine.Subscribe(context.Background(), Subscription{ID: "sub_1", PlanID: "basic"}, time.Time{})
+	assert.Error(t, err)
+}
+
+func TestSubscriptionEngine_MonthEndAnchor(t *testing.T) {
+	client, _ := newSubscriptionTestClient(t)
+	engine := NewSubscriptionEngine(client.Invoice, nil)
+	anchor := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+	now := anchor
+	engine.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.NoError(t, engine.AddPlan(Plan{ID: "basic", Name: "Basic", Amount: 3000, Currency: "NGN", Interval: Monthly}))
+	_, _ = engine.Subscribe(ctx, Subscription{ID: "sub_1", PlanID: "basic", CustomerEmail: "ada@example.com"}, anchor)
+
+	var starts []time.Time
+	for i := 0; i < 4; i++ {
+		_, err := engine.Tick(ctx)
+		assert.NoError(t, err)
+		sub, _ := engine.store.GetSubscription(ctx, "sub_1")
+		starts = append(starts, sub.PeriodStart)
+		now = sub.NextInvoiceAt
+	}
+	assert.Equal(t, []time.Time{
+		anchor,
+		time.Date(2030, 2, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2030, 3, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2030, 4, 30, 9, 0, 0, 0, time.UTC),
+	}, starts)
+	assert.Equal(t, time.Date(2030, 5, 31, 9, 0, 0, 0, time.UTC), now)
+
+	leap := Plan{Interval: Yearly}
+	assert.Equal(t, time.Date(2029, 2, 28, 0, 0, 0, 0, time.UTC), leap.boundary(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), 1))
+	assert.Equal(t, time.Date(2032, 2, 29, 0, 0, 0, 0, time.UTC), leap.boundary(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), 4))
+}
+
+func TestSubscriptionEngine_RunContinuesAfterErrors(t *testing.T) {
+	var mu sync.Mutex
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error":{"name":"BadGateway","message":"Bad gateway"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"id":"11111111-1111-1111-1111-111111111111"}}`))
+	})
+	engine := NewSubscriptionEngine(client.Invoice, nil)
+	engine.now = func() time.Time { return time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC) }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var failed []string
+	engine.OnError = func(id string, err error) { failed = append(failed, id) }
+	engine.OnInvoice = func(ctx context.Context, s Subscription, invoice *Invoice) { cancel() }
+	assert.NoError(t, engine.AddPlan(Plan{ID: "basic", Name: "Basic", Amount: 3000, Currency: "NGN", Interval: Monthly}))
+	_, _ = engine.Subscribe(ctx, Subscription{ID: "sub_1", PlanID: "basic", CustomerEmail: "ada@example.com"}, engine.now())
+
+	err := engine.Run(ctx, time.Millisecond)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"sub_1"}, failed)
+}
@@ -0,0 +1,248 @@
+package busha_commerce_go
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FieldError describes why a single request field is invalid.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// ValidationError is returned when a request fails client-side validation.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e))
+	for i, f := range e {
+		msgs[i] = f.Error()
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// Field returns the error for field, if any.
+func (e ValidationError) Field(field string) (FieldError, bool) {
+	for _, f := range e {
+		if f.Field == field {
+			return f, true
+		}
+	}
+	return FieldError{}, false
+}
+
+type validator struct {
+	errs ValidationError
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validator) url(field string, value *string) {
+	if value == nil {
+		return
+	}
+	if u, err := url.Parse(*value); err != nil || u.Scheme == "" || u.Host == "" {
+		v.add(field, "must be an absolute URL")
+	}
+}
+
+func (v *validator) requestedInfo(field string, fields []RequestedInfoField) {
+	for i, f := range fields {
+		if !f.Valid() {
+			v.add(fmt.Sprintf("%s[%d]", field, i), "must be one of %q, %q or %q", RequestName, RequestEmail, RequestPhoneNumber)
+		}
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// Validate checks the request before it is sent to the API.
+func (r *ChargeRequest) Validate() error {
+	if r == nil {
+		return ValidationError{{Field: "request", Message: "is required"}}
+	}
+	v := new(validator)
+	if r.Reference != nil {
+		if n := len(*r.Reference); n < 5 || n > 100 {
+			v.add("reference", "must be between 5 and 100 characters")
+		}
+	}
+	if r.LocalAmount < 0 {
+		v.add("local_amount", "must not be negative")
+	}
+	if r.FixedPrice {
+		if r.LocalAmount == 0 {
+			v.add("local_amount", "is required for fixed price charges")
+		}
+		if r.LocalCurrency == "" {
+			v.add("local_currency", "is required for fixed price charges")
+		}
+	}
+	v.meta("meta", r.Meta)
+	v.url("success_redirect_url", r.SuccessRedirectURL)
+	v.url("cancel_redirect_url", r.CancelRedirectURL)
+	return v.err()
+}
+
+// Validate checks the request before it is sent to the API.
+func (r *PaymentLinkRequest) Validate() error {
+	if r == nil {
+		return ValidationError{{Field: "request", Message: "is required"}}
+	}
+	v := new(validator)
+	v.required("name", r.Name)
+	if r.LocalAmount < 0 {
+		v.add("local_amount", "must not be negative")
+	}
+	switch r.PaymentLinkType {
+	case FixedPrice:
+		if r.LocalAmount == 0 {
+			v.add("local_amount", "is required for fixed price payment links")
+		}
+		v.required("local_currency", r.LocalCurrency)
+	case Donation:
+		if r.LocalAmount != 0 {
+			v.add("local_amount", "must not be set for donations")
+		}
+	default:
+		v.add("payment_link_type", "must be %q or %q", Donation, FixedPrice)
+	}
+	v.requestedInfo("requested_info", r.RequestedInfo)
+	return v.err()
+}
+
+// Validate checks the request before it is sent to the API.
+func (r *PaymentLinkUpdateRequest) Validate() error {
+	if r == nil {
+		return ValidationError{{Field: "request", Message: "is required"}}
+	}
+	v := new(validator)
+	if r.Name == nil && r.Description == nil && r.PaymentLinkType == nil && r.RequestedInfo == nil &&
+		r.LocalAmount == nil && r.LocalCurrency == nil {
+		v.add("request", "must change at least one field")
+	}
+	if r.Name != nil {
+		v.required("name", *r.Name)
+	}
+	if r.PaymentLinkType != nil && *r.PaymentLinkType != Donation && *r.PaymentLinkType != FixedPrice {
+		v.add("payment_link_type", "must be %q or %q", Donation, FixedPrice)
+	}
+	v.requestedInfo("requested_info", r.RequestedInfo)
+	if r.LocalAmount != nil && *r.LocalAmount < 0 {
+		v.add("local_amount", "must not be negative")
+	}
+	if r.LocalCurrency != nil {
+		v.required("local_currency", *r.LocalCurrency)
+	}
+	return v.err()
+}
+
+// Validate checks the request before it is sent to the API.
+func (r *InvoiceRequest) Validate() error {
+	if r == nil {
+		return ValidationError{{Field: "request", Message: "is required"}}
+	}
+	v := new(validator)
+	v.required("name", r.Name)
+	v.required("customer_email", r.CustomerEmail)
+	if r.CustomerEmail != "" {
+		if _, err := mail.ParseAddress(r.CustomerEmail); err != nil {
+			v.add("customer_email", "must be a valid email address")
+		}
+	}
+	if r.LocalAmount <= 0 {
+		v.add("local_amount", "must be greater than zero")
+	}
+	v.required("local_currency", r.LocalCurrency)
+	if r.DueDate != nil && !r.DueDate.After(time.Now()) {
+		v.add("due_date", "must be in the future")
+	}
+	v.meta("meta", r.Meta)
+	return v.err()
+}
+
+// Validate checks the request before it is sent to the API.
+func (r *InvoiceUpdateRequest) Validate() error {
+	if r == nil {
+		return ValidationError{{Field: "request", Message: "is required"}}
+	}
+	v := new(validator)
+	if r.Name == nil && r.CustomerEmail == nil && r.CustomerName == nil && r.Description == nil &&
+		r.LocalAmount == nil && r.LocalCurrency == nil && r.DueDate == nil {
+		v.add("request", "must change at least one field")
+	}
+	if r.Name != nil {
+		v.required("name", *r.Name)
+	}
+	if r.CustomerEmail != nil {
+		if _, err := mail.ParseAddress(*r.CustomerEmail); err != nil {
+			v.add("customer_email", "must be a valid email address")
+		}
+	}
+	if r.LocalAmount != nil && *r.LocalAmount <= 0 {
+		v.add("local_amount", "must be greater than zero")
+	}
+	if r.LocalCurrency != nil {
+		v.required("local_currency", *r.LocalCurrency)
+	}
+	if r.DueDate != nil && !r.DueDate.After(time.Now()) {
+		v.add("due_date", "must be in the future")
+	}
+	return v.err()
+}
+
+// Validate checks the request before it is sent to the API.
+func (r *AddressRequest) Validate() error {
+	if r == nil {
+		return ValidationError{{Field: "request", Message: "is required"}}
+	}
+	v := new(validator)
+	v.required("currency_id", r.CurrencyId)
+	if len(r.Chains) == 0 {
+		v.add("chains", "must not be empty")
+	}
+	for i, chain := range r.Chains {
+		if strings.TrimSpace(chain) == "" {
+			v.add(fmt.Sprintf("chains[%d]", i), "must not be empty")
+		}
+	}
+	return v.err()
+}
+
+// SetValidation turns automatic validation of requests passed to Create and
+// Update on or off. It is on by default.
+func (c *Client) SetValidation(enabled bool) {
+	c.skipValidation = !enabled
+}
+
+type validatable interface {
+	Validate() error
+}
+
+func (c *Client) validate(req validatable) error {
+	if c.skipValidation {
+		return nil
+	}
+	return req.Validate()
+}
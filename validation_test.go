@@ -0,0 +1,154 @@
+package busha_commerce_go
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChargeRequest_Validate(t *testing.T) {
+	short, long := "abc", strings.Repeat("a", 101)
+	badURL := "/success"
+	tests := []struct {
+		name   string
+		req    *ChargeRequest
+		fields []string
+	}{
+		{name: "valid open charge", req: &ChargeRequest{}},
+		{name: "valid fixed charge", req: &ChargeRequest{FixedPrice: true, LocalAmount: 5000, LocalCurrency: "NGN"}},
+		{name: "fixed charge without amount or currency", req: &ChargeRequest{FixedPrice: true}, fields: []string{"local_amount", "local_currency"}},
+		{name: "short reference", req: &ChargeRequest{Reference: &short}, fields: []string{"reference"}},
+		{name: "long reference", req: &ChargeRequest{Reference: &long}, fields: []string{"reference"}},
+		{name: "invalid meta and url", req: &ChargeRequest{Meta: json.RawMessage(`{`), SuccessRedirectURL: &badURL}, fields: []string{"meta", "success_redirect_url"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFieldErrors(t, tt.req.Validate(), tt.fields)
+		})
+	}
+}
+
+func TestInvoiceRequest_Validate(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	valid := InvoiceRequest{Name: "Services", CustomerEmail: "syz@g.com", LocalAmount: 5000, LocalCurrency: "NGN"}
+	withDue := func(r InvoiceRequest, due *time.Time) *InvoiceRequest { r.DueDate = due; return &r }
+	withEmail := func(r InvoiceRequest, email string) *InvoiceRequest { r.CustomerEmail = email; return &r }
+
+	tests := []struct {
+		name   string
+		req    *InvoiceRequest
+		fields []string
+	}{
+		{name: "valid without due date", req: &valid},
+		{name: "valid with future due date", req: withDue(valid, &future)},
+		{name: "past due date", req: withDue(valid, &past), fields: []string{"due_date"}},
+		{name: "invalid email", req: withEmail(valid, "not-an-email"), fields: []string{"customer_email"}},
+		{name: "empty", req: &InvoiceRequest{}, fields: []string{"name", "customer_email", "local_amount", "local_currency"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFieldErrors(t, tt.req.Validate(), tt.fields)
+		})
+	}
+}
+
+func TestPaymentLinkRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *PaymentLinkRequest
+		fields []string
+	}{
+		{name: "valid fixed price", req: &PaymentLinkRequest{Name: "Link", PaymentLinkType: FixedPrice, LocalAmount: 5000, LocalCurrency: "NGN"}},
+		{name: "valid donation", req: &PaymentLinkRequest{Name: "NGO", PaymentLinkType: Donation}},
+		{name: "fixed price without amount", req: &PaymentLinkRequest{Name: "Link", PaymentLinkType: FixedPrice, LocalCurrency: "NGN"}, fields: []string{"local_amount"}},
+		{name: "donation with amount", req: &PaymentLinkRequest{Name: "NGO", PaymentLinkType: Donation, LocalAmount: 5000}, fields: []string{"local_amount"}},
+		{name: "unknown type", req: &PaymentLinkRequest{Name: "Link"}, fields: []string{"payment_link_type"}},
+		{name: "unknown requested info", req: &PaymentLinkRequest{Name: "NGO", PaymentLinkType: Donation, RequestedInfo: []RequestedInfoField{RequestEmail, "phone"}}, fields: []string{"requested_info[1]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFieldErrors(t, tt.req.Validate(), tt.fields)
+		})
+	}
+}
+
+func TestPaymentLinkUpdateRequest_Validate(t *testing.T) {
+	name, empty, amount := "Link", "", -1.0
+	kind := PaymentLinkType("subscription")
+	assertFieldErrors(t, (&PaymentLinkUpdateRequest{}).Validate(), []string{"request"})
+	assertFieldErrors(t, (&PaymentLinkUpdateRequest{Name: &empty, PaymentLinkType: &kind, LocalAmount: &amount}).Validate(),
+		[]string{"name", "payment_link_type", "local_amount"})
+	assertFieldErrors(t, (&PaymentLinkUpdateRequest{RequestedInfo: []RequestedInfoField{"phone"}}).Validate(),
+		[]string{"requested_info[0]"})
+	assert.NoError(t, (&PaymentLinkUpdateRequest{Name: &name}).Validate())
+}
+
+func TestAddressRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&AddressRequest{CurrencyId: "USDT", Chains: []string{"TRX"}}).Validate())
+	assertFieldErrors(t, (&AddressRequest{CurrencyId: "USDT", Chains: []string{""}}).Validate(), []string{"chains[0]"})
+}
+
+func TestClient_SetValidation(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"name":"ValidationError","message":"Invalid request"}}`))
+	})
+
+	_, err := client.Charge.Create(&ChargeRequest{FixedPrice: true})
+	assert.IsType(t, ValidationError{}, err)
+	assert.Equal(t, 0, calls)
+
+	client.SetValidation(false)
+	_, err = client.Charge.Create(&ChargeRequest{FixedPrice: true})
+	assert.IsType(t, ErrResponse{}, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_ValidationResponse(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+	})
+
+	// Callers reading the response after an error must not crash.
+	charge, err := client.Charge.Create(&ChargeRequest{FixedPrice: true})
+	assert.Error(t, err)
+	assert.NotNil(t, charge)
+	address, err := client.Address.Create(&AddressRequest{})
+	assert.Error(t, err)
+	assert.NotNil(t, address)
+	link, err := client.PaymentLink.Create(&PaymentLinkRequest{})
+	assert.Error(t, err)
+	assert.NotNil(t, link)
+	invoice, err := client.Invoice.Create(&InvoiceRequest{})
+	assert.Error(t, err)
+	assert.NotNil(t, invoice)
+	assert.Equal(t, 0, calls)
+
+	_, err = client.PaymentLink.CreateCharge("link", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func assertFieldErrors(t *testing.T, err error, fields []string) {
+	t.Helper()
+	if len(fields) == 0 {
+		assert.NoError(t, err)
+		return
+	}
+	if !assert.IsType(t, ValidationError{}, err) {
+		return
+	}
+	var got []string
+	for _, f := range err.(ValidationError) {
+		got = append(got, f.Field)
+	}
+	assert.Equal(t, fields, got)
+}